@@ -1,13 +1,18 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pteich/crdlens/internal/cli"
 	"github.com/pteich/crdlens/internal/config"
 	"github.com/pteich/crdlens/internal/k8s"
 	"github.com/pteich/crdlens/internal/ui"
+	"k8s.io/klog/v2"
 )
 
 func main() {
@@ -17,12 +22,22 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Arguments after the flags select a non-interactive command
+	if flag.NArg() > 0 {
+		env := &cli.Env{Config: cfg, Stdout: os.Stdout, Stderr: os.Stderr}
+		os.Exit(cli.Run(context.Background(), env, flag.Args()))
+	}
+
 	client, err := k8s.NewClient(cfg)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing Kubernetes client: %v\n", err)
 		os.Exit(1)
 	}
 
+	// client-go logs through klog to stderr, which would corrupt the alt screen
+	klog.LogToStderr(false)
+	klog.SetOutput(io.Discard)
+
 	m := ui.NewModel(cfg, client)
 
 	p := tea.NewProgram(m, tea.WithAltScreen())
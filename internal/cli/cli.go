@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pteich/crdlens/internal/config"
+	"github.com/pteich/crdlens/internal/k8s"
+)
+
+// Env holds what commands need to talk to the cluster and to the user
+type Env struct {
+	Config *config.Config
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// NewClient creates the Kubernetes client, it is only called by commands that need it
+	NewClient func(cfg *config.Config) (*k8s.Client, error)
+}
+
+// Command is a non-interactive subcommand
+type Command struct {
+	Name    string
+	Usage   string // Arguments, e.g. "<crd> [--full]"
+	Summary string
+	Run     func(ctx context.Context, env *Env, args []string) error
+}
+
+// ExitError makes Run return a specific exit code, e.g. when validation found problems
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+var commands = map[string]Command{}
+
+// register adds a command, it is called from init functions of the command files
+func register(cmd Command) {
+	commands[cmd.Name] = cmd
+}
+
+// Run executes the subcommand in args[0] and returns the exit code
+func Run(ctx context.Context, env *Env, args []string) int {
+	if len(args) == 0 {
+		printUsage(env.Stderr)
+		return 2
+	}
+
+	if args[0] == "help" {
+		printUsage(env.Stdout)
+		return 0
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(env.Stderr, "Unknown command %q\n\n", args[0])
+		printUsage(env.Stderr)
+		return 2
+	}
+
+	if err := cmd.Run(ctx, env, args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		var exitErr *ExitError
+		if errors.As(err, &exitErr) {
+			if exitErr.Err != nil {
+				fmt.Fprintf(env.Stderr, "Error: %v\n", exitErr.Err)
+			}
+			return exitErr.Code
+		}
+		fmt.Fprintf(env.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// printUsage lists all commands
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: crdlens [flags] [command]")
+	fmt.Fprintln(w, "\nWithout a command the interactive UI is started.\n\nCommands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cmd := commands[name]
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.Name, cmd.Summary)
+	}
+}
+
+// newFlagSet creates a flag set for a command that prints its usage to stderr
+func newFlagSet(env *Env, cmd string, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	fs.SetOutput(env.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(env.Stderr, "Usage: crdlens %s %s\n", cmd, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseArgs parses flags that may appear before or after positional arguments
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// client creates the Kubernetes client for a command
+func (env *Env) client() (*k8s.Client, error) {
+	newClient := env.NewClient
+	if newClient == nil {
+		newClient = k8s.NewClient
+	}
+	return newClient(env.Config)
+}
@@ -0,0 +1,347 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pteich/crdlens/internal/config"
+	"github.com/pteich/crdlens/internal/k8s"
+)
+
+func testCRD() *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind:       "Widget",
+				Plural:     "widgets",
+				Singular:   "widget",
+				ShortNames: []string{"wd"},
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:    "v1",
+				Served:  true,
+				Storage: true,
+				Schema: &apiextensionsv1.CustomResourceValidation{
+					OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"spec": {
+								Type:     "object",
+								Required: []string{"size"},
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"size":  {Type: "integer"},
+									"color": {Type: "string"},
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+	}
+}
+
+var widgetGVR = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+
+// runTest runs a command against a fake cluster and returns exit code, stdout and stderr.
+// CRDs, custom resources (unstructured) and built-in objects go to their respective fake clients.
+func runTest(t *testing.T, objects []runtime.Object, args ...string) (int, string, string) {
+	t.Helper()
+
+	var crds, resources, kubeObjects []runtime.Object
+	for _, obj := range objects {
+		switch obj.(type) {
+		case *apiextensionsv1.CustomResourceDefinition:
+			crds = append(crds, obj)
+		case *unstructured.Unstructured:
+			resources = append(resources, obj)
+		default:
+			kubeObjects = append(kubeObjects, obj)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	env := &Env{
+		Config: config.DefaultConfig(),
+		Stdout: &stdout,
+		Stderr: &stderr,
+		NewClient: func(*config.Config) (*k8s.Client, error) {
+			return &k8s.Client{
+				ApiextensionsClient: apiextensionsfake.NewSimpleClientset(crds...),
+				DynamicClient: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+					map[schema.GroupVersionResource]string{widgetGVR: "WidgetList"}, resources...),
+				KubeClient: kubefake.NewSimpleClientset(kubeObjects...),
+			}, nil
+		},
+	}
+	code := Run(context.Background(), env, args)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	code, _, stderr := runTest(t, nil, "nope")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, `Unknown command "nope"`)
+	assert.Contains(t, stderr, "sample")
+}
+
+func TestRun_Sample(t *testing.T) {
+	objects := []runtime.Object{testCRD()}
+
+	code, stdout, stderr := runTest(t, objects, "sample", "wd")
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "kind: Widget")
+	assert.Contains(t, stdout, "size: 0")
+	assert.NotContains(t, stdout, "color")
+
+	// Flags may follow the CRD name
+	code, stdout, _ = runTest(t, objects, "sample", "widgets.example.com", "--full")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, `color: ""`)
+
+	code, _, stderr = runTest(t, objects, "sample", "gadgets")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "not found")
+
+	code, _, _ = runTest(t, objects, "sample")
+	assert.Equal(t, 2, code)
+
+	// CRD files accept the same names as the cluster
+	crdFile := filepath.Join(t.TempDir(), "crds.yaml")
+	crdYAML, err := yaml.Marshal(testCRD())
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(crdFile, append([]byte("apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\n"), crdYAML...), 0o644))
+	for _, name := range []string{"widget", "WD", "Widgets"} {
+		code, stdout, stderr = runTest(t, nil, "sample", name, "--file", crdFile)
+		assert.Equal(t, 0, code, stderr)
+		assert.Contains(t, stdout, "kind: Widget")
+	}
+
+	code, _, stderr = runTest(t, nil, "sample", "gadgets", "--file", crdFile)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "no CRD \"gadgets\" found")
+}
+
+func TestRun_Validate(t *testing.T) {
+	dir := t.TempDir()
+	crdFile := filepath.Join(dir, "crds", "widget.yaml")
+	crdYAML, err := yaml.Marshal(testCRD())
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(crdFile), 0o755))
+	require.NoError(t, os.WriteFile(crdFile, append([]byte("apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\n"), crdYAML...), 0o644))
+
+	valid := filepath.Join(dir, "valid.yaml")
+	require.NoError(t, os.WriteFile(valid, []byte(`apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: ok
+spec:
+  size: 3
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-custom-resource
+`), 0o644))
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte(`apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: broken
+spec:
+  colour: red
+`), 0o644))
+
+	// CRDs from files, no cluster needed
+	code, stdout, stderr := runTest(t, nil, "validate", "-f", valid, "--crds", crdFile)
+	assert.Equal(t, 0, code, stdout)
+	assert.Contains(t, stderr, "1 resources validated, 1 skipped without CRD, 0 violations")
+
+	code, stdout, _ = runTest(t, nil, "validate", "-f", invalid, "--crds", crdFile)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout, invalid+": Widget/broken: spec.colour:")
+	assert.Contains(t, stdout, "(rule: unknown field)")
+	assert.Contains(t, stdout, "spec.size: Required value (rule: required)")
+
+	// CRDs from the cluster
+	code, _, stderr = runTest(t, []runtime.Object{testCRD()}, "validate", "-f", dir)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "2 resources validated")
+
+	code, _, _ = runTest(t, nil, "validate")
+	assert.Equal(t, 2, code)
+}
+
+func testWidget(name string, generation, observed int64, ready string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("example.com/v1")
+	u.SetKind("Widget")
+	u.SetNamespace("default")
+	u.SetName(name)
+	u.SetUID(k8stypes.UID(name + "-uid"))
+	u.SetGeneration(generation)
+	u.SetCreationTimestamp(metav1.NewTime(time.Now().Add(-time.Hour)))
+	u.Object["status"] = map[string]interface{}{
+		"observedGeneration": observed,
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": ready, "reason": "Reconciled"},
+		},
+	}
+	return u
+}
+
+func TestRun_CRDs(t *testing.T) {
+	code, stdout, stderr := runTest(t, []runtime.Object{testCRD()}, "crds")
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "NAME")
+	assert.Regexp(t, `widgets.example.com\s+example.com\s+Widget\s+v1\s+Namespaced`, stdout)
+
+	code, stdout, _ = runTest(t, []runtime.Object{testCRD()}, "crds", "-o", "json")
+	assert.Equal(t, 0, code)
+	var crds []crdSummary
+	require.NoError(t, json.Unmarshal([]byte(stdout), &crds))
+	require.Len(t, crds, 1)
+	assert.Equal(t, []string{"v1"}, crds[0].Versions)
+
+	code, _, stderr = runTest(t, nil, "crds", "-o", "xml")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "unknown output format")
+}
+
+func TestRun_ListAndGet(t *testing.T) {
+	objects := []runtime.Object{
+		testCRD(),
+		testWidget("ok", 2, 2, "True"),
+		testWidget("broken", 1, 1, "False"),
+	}
+
+	code, stdout, stderr := runTest(t, objects, "list", "wd", "-n", "default")
+	assert.Equal(t, 0, code, stderr)
+	assert.Regexp(t, `ok\s+default\s+Ready\s+Idle\s+0`, stdout)
+	assert.Regexp(t, `broken\s+default\s+NotReady\s+Error\s+0`, stdout)
+
+	code, stdout, _ = runTest(t, objects, "list", "widgets", "-A", "-o", "yaml")
+	assert.Equal(t, 0, code)
+	var summaries []resourceSummary
+	require.NoError(t, yaml.Unmarshal([]byte(stdout), &summaries))
+	assert.Len(t, summaries, 2)
+
+	code, _, stderr = runTest(t, objects, "list", "wd", "-n", "other")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stderr, "No widgets.example.com found")
+
+	code, stdout, stderr = runTest(t, objects, "get", "wd", "broken", "-n", "default")
+	assert.Equal(t, 0, code, stderr)
+	assert.Regexp(t, `Reconcile State:\s+Error \(controller reports NotReady\)`, stdout)
+	assert.Regexp(t, `Ready\s+False\s+Reconciled`, stdout)
+	assert.Regexp(t, `KStatus:\s+InProgress \(`, stdout)
+
+	code, stdout, _ = runTest(t, objects, "get", "wd", "ok", "-n", "default", "-o", "json")
+	assert.Equal(t, 0, code)
+	var s resourceSummary
+	require.NoError(t, json.Unmarshal([]byte(stdout), &s))
+	assert.Equal(t, "Ready", s.Ready)
+	assert.Equal(t, "Idle", s.ReconcileState)
+	assert.Equal(t, "Current", s.KStatus)
+
+	code, _, stderr = runTest(t, objects, "get", "wd", "missing", "-n", "default")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "not found")
+
+	code, _, _ = runTest(t, objects, "get", "wd")
+	assert.Equal(t, 2, code)
+}
+
+func TestRun_Status(t *testing.T) {
+	healthy := []runtime.Object{testCRD(), testWidget("ok", 2, 2, "True")}
+	code, stdout, stderr := runTest(t, healthy, "status", "wd", "-n", "default")
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "widgets.example.com in namespace default: 1 resources")
+	assert.Regexp(t, `Ready:\s+Ready=1`, stdout)
+
+	// Too many recent Warning events make a ready resource fail as well
+	warning := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "ok.warning", Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{UID: "ok-uid"},
+		Type:           corev1.EventTypeWarning,
+		Count:          5,
+		LastTimestamp:  metav1.Now(),
+	}
+	objects := append(healthy, testWidget("broken", 1, 1, "False"), warning)
+	code, stdout, _ = runTest(t, objects, "status", "wd", "-n", "default", "-o", "json")
+	assert.Equal(t, 1, code)
+	var report statusReport
+	require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, map[string]int{"Ready": 1, "NotReady": 1}, report.Ready)
+	assert.Equal(t, map[string]int{"Error": 2}, report.States)
+	require.Len(t, report.Unhealthy, 2)
+	assert.Equal(t, "5 recent Warning events", report.Unhealthy[1].Reason)
+}
+
+func TestRun_Report(t *testing.T) {
+	objects := []runtime.Object{
+		testCRD(),
+		testWidget("ok", 2, 2, "True"),
+		testWidget("broken|pipe", 1, 1, "False"),
+		testWidget("drifting", 3, 2, "True"),
+	}
+
+	code, stdout, stderr := runTest(t, objects, "report")
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Scanned 3 resources of 1 CRDs, 2 unhealthy.")
+	assert.Contains(t, stdout, "## widgets.example.com")
+	assert.Contains(t, stdout, "### Namespace `default`, controller `-`")
+	assert.Contains(t, stdout, `| broken\|pipe | NotReady | 0 | - | NotReady |`)
+	assert.Contains(t, stdout, "| drifting | Ready | 1 | - | drift 1 |")
+	assert.NotContains(t, stdout, "| ok |")
+
+	code, stdout, _ = runTest(t, objects, "report", "-o", "html")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "<h2>widgets.example.com</h2>")
+	assert.Contains(t, stdout, "<td>broken|pipe</td><td>NotReady</td>")
+
+	code, stdout, _ = runTest(t, objects, "report", "-n", "default", "-o", "json")
+	assert.Equal(t, 0, code)
+	var report reportOutput
+	require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+	assert.Equal(t, "default", report.Namespace)
+	assert.Equal(t, 2, report.Unhealthy)
+	require.Len(t, report.Groups, 1)
+	assert.Len(t, report.Groups[0].Resources, 2)
+
+	code, _, _ = runTest(t, objects, "report", "-o", "pdf")
+	assert.Equal(t, 2, code)
+}
+
+func TestRun_Serve_Usage(t *testing.T) {
+	code, _, stderr := runTest(t, nil, "serve")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "Usage: crdlens serve --metrics :9090")
+
+	code, _, _ = runTest(t, nil, "serve", "--metrics", ":9090", "--interval", "0s")
+	assert.Equal(t, 2, code)
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+)
+
+func init() {
+	register(Command{
+		Name:    "crds",
+		Usage:   "[-o table|json|yaml]",
+		Summary: "List the CRDs of the cluster",
+		Run:     runCRDs,
+	})
+}
+
+// crdSummary is a CRD in the output of the crds command
+type crdSummary struct {
+	Name     string   `json:"name"`
+	Group    string   `json:"group"`
+	Kind     string   `json:"kind"`
+	Version  string   `json:"version"`
+	Versions []string `json:"versions"`
+	Scope    string   `json:"scope"`
+}
+
+func runCRDs(ctx context.Context, env *Env, args []string) error {
+	fs := newFlagSet(env, "crds", commands["crds"].Usage)
+	output := outputFlag(fs)
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		fs.Usage()
+		return &ExitError{Code: 2}
+	}
+
+	client, err := env.client()
+	if err != nil {
+		return err
+	}
+	crds, err := client.Discovery().ListCRDs(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(crds, func(i, j int) bool { return crds[i].Name < crds[j].Name })
+
+	summaries := make([]crdSummary, 0, len(crds))
+	for _, crd := range crds {
+		s := crdSummary{Name: crd.Name, Group: crd.Group, Kind: crd.Kind, Version: crd.Version, Scope: crd.Scope}
+		for _, v := range crd.Versions {
+			if v.Served {
+				s.Versions = append(s.Versions, v.Name)
+			}
+		}
+		summaries = append(summaries, s)
+	}
+
+	return printOutput(env.Stdout, *output, summaries, func(w io.Writer) {
+		fmt.Fprintln(w, "NAME\tGROUP\tKIND\tVERSION\tSCOPE")
+		for _, s := range summaries {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", s.Name, s.Group, s.Kind, s.Version, s.Scope)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pteich/crdlens/internal/k8s"
+)
+
+func init() {
+	register(Command{
+		Name:    "get",
+		Usage:   "<crd> <name> [-n namespace] [-o table|json|yaml]",
+		Summary: "Show the ready state, reconcile state and conditions of a resource",
+		Run:     runGet,
+	})
+}
+
+func runGet(ctx context.Context, env *Env, args []string) error {
+	fs := newFlagSet(env, "get", commands["get"].Usage)
+	ns := addNamespaceFlags(fs, env, false)
+	output := outputFlag(fs)
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		fs.Usage()
+		return &ExitError{Code: 2}
+	}
+
+	lister, err := newResourceLister(ctx, env, positional[0])
+	if err != nil {
+		return err
+	}
+	namespace := ns.resolve(env, lister.client, lister.crd)
+	res, err := lister.client.Dynamic().GetResource(ctx, lister.crd.GVR, namespace, positional[1])
+	if err != nil {
+		return err
+	}
+	lister.loadWarnings(ctx, namespace)
+	s := lister.summarize(k8s.WithKStatus(*res))
+
+	return printOutput(env.Stdout, *output, s, func(w io.Writer) {
+		fmt.Fprintf(w, "Name:\t%s\n", s.Name)
+		fmt.Fprintf(w, "Namespace:\t%s\n", orDash(s.Namespace))
+		fmt.Fprintf(w, "Kind:\t%s\n", s.Kind)
+		fmt.Fprintf(w, "Ready:\t%s\n", s.Ready)
+		if s.KStatus != "" {
+			fmt.Fprintf(w, "KStatus:\t%s (%s)\n", s.KStatus, s.KStatusMessage)
+		}
+		fmt.Fprintf(w, "Reconcile State:\t%s (%s)\n", s.ReconcileState, s.Reason)
+		fmt.Fprintf(w, "Generation:\t%d (observed %d, drift %d)\n", s.Generation, s.ObservedGeneration, s.Drift)
+		fmt.Fprintf(w, "Lag:\t%s\n", orDash(s.Lag))
+		fmt.Fprintf(w, "Silence:\t%s\n", orDash(s.Silence))
+		fmt.Fprintf(w, "Controller:\t%s\n", orDash(s.Controller))
+		fmt.Fprintf(w, "Recent Warnings:\t%d\n", s.RecentWarnings)
+		if len(s.Finalizers) > 0 {
+			fmt.Fprintf(w, "Finalizers:\t%s\n", strings.Join(s.Finalizers, ", "))
+		}
+		fmt.Fprintf(w, "Age:\t%s\n", s.age(lister.now))
+
+		if len(s.Conditions) == 0 {
+			return
+		}
+		fmt.Fprintln(w, "\nTYPE\tSTATUS\tREASON\tMESSAGE")
+		for _, c := range s.Conditions {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Type, c.Status, orDash(c.Reason), c.Message)
+		}
+	})
+}
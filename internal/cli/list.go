@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pteich/crdlens/internal/k8s"
+	"github.com/pteich/crdlens/internal/types"
+)
+
+func init() {
+	register(Command{
+		Name:    "list",
+		Usage:   "<crd> [-n namespace | -A] [-l selector] [--field-selector selector] [-o table|json|yaml]",
+		Summary: "List the resources of a CRD with their ready and reconcile state",
+		Run:     runList,
+	})
+}
+
+// namespaceFlags are the namespace flags shared by list, get and status
+type namespaceFlags struct {
+	namespace     *string
+	allNamespaces *bool
+}
+
+// addNamespaceFlags adds -n/--namespace and, if all is set, -A/--all-namespaces
+func addNamespaceFlags(fs *flag.FlagSet, env *Env, all bool) namespaceFlags {
+	f := namespaceFlags{namespace: new(string), allNamespaces: new(bool)}
+	fs.StringVar(f.namespace, "n", "", "namespace of the resources (defaults to the namespace of the context)")
+	fs.StringVar(f.namespace, "namespace", "", "same as -n")
+	if all {
+		*f.allNamespaces = env.Config.AllNamespaces
+		fs.BoolVar(f.allNamespaces, "A", *f.allNamespaces, "list resources in all namespaces")
+		fs.BoolVar(f.allNamespaces, "all-namespaces", *f.allNamespaces, "same as -A")
+	}
+	return f
+}
+
+// resolve returns the namespace to use for a CRD, empty means all namespaces
+func (f namespaceFlags) resolve(env *Env, client *k8s.Client, crd types.CRDInfo) string {
+	if crd.Scope == "Cluster" {
+		return ""
+	}
+	if *f.namespace != "" {
+		return *f.namespace
+	}
+	if *f.allNamespaces {
+		return ""
+	}
+	if env.Config.Namespace != "" {
+		return env.Config.Namespace
+	}
+	if client.Namespace != "" {
+		return client.Namespace
+	}
+	return "default"
+}
+
+// resourceLister loads the resources of a CRD and classifies them
+type resourceLister struct {
+	env      *Env
+	client   *k8s.Client
+	crd      types.CRDInfo
+	warnings map[string]int
+	now      time.Time
+}
+
+// newResourceLister connects to the cluster and looks up the CRD
+func newResourceLister(ctx context.Context, env *Env, crdName string) (*resourceLister, error) {
+	client, err := env.client()
+	if err != nil {
+		return nil, err
+	}
+	crd, err := client.Discovery().GetCRDInfo(ctx, crdName)
+	if err != nil {
+		return nil, err
+	}
+	return &resourceLister{env: env, client: client, crd: crd, now: time.Now()}, nil
+}
+
+// loadWarnings counts the recent Warning events in the namespace. Events are optional,
+// without them the reconcile state only misses the warning check.
+func (l *resourceLister) loadWarnings(ctx context.Context, namespace string) {
+	if l.client.KubeClient == nil {
+		return
+	}
+	warnings, err := l.client.Events(namespace).CountRecentWarnings(ctx, l.now.Add(-k8s.RecentWarningWindow))
+	if err != nil {
+		fmt.Fprintf(l.env.Stderr, "Warning: %v\n", err)
+		return
+	}
+	l.warnings = warnings
+}
+
+// list returns the summaries of all resources in the namespace that match opts
+func (l *resourceLister) list(ctx context.Context, namespace string, opts k8s.ListResourcesOptions) ([]resourceSummary, error) {
+	resources, err := l.client.Dynamic().ListAllResources(ctx, l.crd.GVR, namespace, opts)
+	if err != nil {
+		return nil, err
+	}
+	l.loadWarnings(ctx, namespace)
+
+	summaries := make([]resourceSummary, 0, len(resources))
+	for _, res := range resources {
+		summaries = append(summaries, l.summarize(res))
+	}
+	return summaries, nil
+}
+
+// summarize classifies a resource with the thresholds of its CRD and controller
+func (l *resourceLister) summarize(res types.Resource) resourceSummary {
+	th := l.env.Config.Reconcile.ThresholdsFor(l.crd.Name, res.ControllerManager)
+	return summarize(res, th, l.warnings[res.UID], l.now)
+}
+
+func runList(ctx context.Context, env *Env, args []string) error {
+	fs := newFlagSet(env, "list", commands["list"].Usage)
+	ns := addNamespaceFlags(fs, env, true)
+	labelSelector := fs.String("l", "", "label selector, e.g. app=web")
+	fs.StringVar(labelSelector, "selector", "", "same as -l")
+	fieldSelector := fs.String("field-selector", "", "field selector, e.g. metadata.name=web")
+	output := outputFlag(fs)
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return &ExitError{Code: 2}
+	}
+
+	lister, err := newResourceLister(ctx, env, positional[0])
+	if err != nil {
+		return err
+	}
+	namespace := ns.resolve(env, lister.client, lister.crd)
+	summaries, err := lister.list(ctx, namespace, k8s.ListResourcesOptions{
+		LabelSelector: *labelSelector,
+		FieldSelector: *fieldSelector,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printOutput(env.Stdout, *output, summaries, func(w io.Writer) {
+		if len(summaries) == 0 {
+			fmt.Fprintf(env.Stderr, "No %s found\n", lister.crd.Name)
+			return
+		}
+		fmt.Fprintln(w, "NAME\tNAMESPACE\tREADY\tSTATE\tDRIFT\tCONTROLLER\tAGE")
+		for _, s := range summaries {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+				s.Name, orDash(s.Namespace), s.Ready, s.ReconcileState, s.Drift, orDash(s.Controller), s.age(lister.now))
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pteich/crdlens/internal/types"
+)
+
+// Output formats supported by the -o flag
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// outputFlag adds the -o and --output flags to a command
+func outputFlag(fs *flag.FlagSet) *string {
+	output := fs.String("o", outputTable, "output format: table, json or yaml")
+	fs.StringVar(output, "output", outputTable, "same as -o")
+	return output
+}
+
+// printOutput prints v as JSON or YAML, or calls table with a tabwriter for the table format
+func printOutput(w io.Writer, format string, v interface{}, table func(w io.Writer)) error {
+	switch format {
+	case outputJSON:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case outputYAML:
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case outputTable, "":
+		tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		table(tw)
+		return tw.Flush()
+	default:
+		return &ExitError{Code: 2, Err: fmt.Errorf("unknown output format %q, use table, json or yaml", format)}
+	}
+}
+
+// resourceSummary is the controller-aware view of a resource printed by list, get and status
+type resourceSummary struct {
+	Name               string             `json:"name"`
+	Namespace          string             `json:"namespace,omitempty"`
+	Kind               string             `json:"kind"`
+	Ready              string             `json:"ready"`
+	KStatus            string             `json:"kstatus,omitempty"`
+	KStatusMessage     string             `json:"kstatusMessage,omitempty"`
+	ReconcileState     string             `json:"reconcileState"`
+	Reason             string             `json:"reason"`
+	Generation         int64              `json:"generation"`
+	ObservedGeneration int64              `json:"observedGeneration"`
+	Drift              int64              `json:"drift"`
+	Lag                string             `json:"lag,omitempty"`
+	Silence            string             `json:"silence,omitempty"`
+	Controller         string             `json:"controller,omitempty"`
+	RecentWarnings     int                `json:"recentWarnings"`
+	Suspended          bool               `json:"suspended,omitempty"`
+	Terminating        bool               `json:"terminating,omitempty"`
+	Finalizers         []string           `json:"finalizers,omitempty"`
+	CreatedAt          time.Time          `json:"createdAt"`
+	Conditions         []conditionSummary `json:"conditions,omitempty"`
+
+	state types.ReconcileState
+}
+
+// conditionSummary is a status condition in the output of get and list
+type conditionSummary struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason,omitempty"`
+	Message            string    `json:"message,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+// summarize classifies a resource with the given thresholds and number of recent Warning events
+func summarize(res types.Resource, th types.ReconcileThresholds, warnings int, now time.Time) resourceSummary {
+	state, reason := res.ClassifyReconcile(th, warnings, now)
+	s := resourceSummary{
+		Name:               res.Name,
+		Namespace:          res.Namespace,
+		Kind:               res.Kind,
+		Ready:              res.ReadyStatus(),
+		KStatus:            res.KStatus,
+		KStatusMessage:     res.KStatusMessage,
+		ReconcileState:     state.String(),
+		Reason:             reason,
+		Generation:         res.Generation,
+		ObservedGeneration: res.ObservedGeneration,
+		Drift:              res.Drift(),
+		Controller:         res.ControllerManager,
+		RecentWarnings:     warnings,
+		Suspended:          res.Suspended,
+		Terminating:        res.IsTerminating(),
+		Finalizers:         res.Finalizers,
+		CreatedAt:          res.CreatedAt,
+		state:              state,
+	}
+	if lag := res.Lag(); lag > 0 {
+		s.Lag = lag.Round(time.Second).String()
+	}
+	if !res.LastStatusWrite.IsZero() {
+		s.Silence = res.Silence().Round(time.Second).String()
+	}
+	for _, c := range res.Conditions {
+		s.Conditions = append(s.Conditions, conditionSummary(c))
+	}
+	return s
+}
+
+// unhealthy returns true for resources that fail the status command
+func (s resourceSummary) unhealthy() bool {
+	return s.Ready == "NotReady" || s.state == types.ReconcileStateStuck || s.state == types.ReconcileStateError
+}
+
+// age formats the age of the resource like kubectl
+func (s resourceSummary) age(now time.Time) string {
+	if s.CreatedAt.IsZero() {
+		return "-"
+	}
+	return duration.HumanDuration(now.Sub(s.CreatedAt))
+}
+
+// orDash returns "-" for empty values in tables
+func orDash(v string) string {
+	if v == "" {
+		return "-"
+	}
+	return v
+}
@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pteich/crdlens/internal/k8s"
+)
+
+func init() {
+	register(Command{
+		Name:    "report",
+		Usage:   "[-n namespace] [--silence 24h] [--concurrency 4] [--page-size 100] [-o markdown|html|json]",
+		Summary: "Report unhealthy custom resources of all CRDs, grouped by CRD, namespace and controller",
+		Run:     runReport,
+	})
+}
+
+// reportOutput is the report as printed by the report command
+type reportOutput struct {
+	GeneratedAt      time.Time           `json:"generatedAt"`
+	Namespace        string              `json:"namespace,omitempty"`
+	ScannedCRDs      int                 `json:"scannedCRDs"`
+	ScannedResources int                 `json:"scannedResources"`
+	Unhealthy        int                 `json:"unhealthy"`
+	Failed           map[string]string   `json:"failed,omitempty"`
+	Groups           []reportGroupOutput `json:"groups"`
+}
+
+// reportGroupOutput are the unhealthy resources of a CRD in a namespace handled by the same controller
+type reportGroupOutput struct {
+	CRD        string                 `json:"crd"`
+	Namespace  string                 `json:"namespace,omitempty"`
+	Controller string                 `json:"controller,omitempty"`
+	Resources  []reportResourceOutput `json:"resources"`
+}
+
+// reportResourceOutput is an unhealthy resource in the report
+type reportResourceOutput struct {
+	Name    string   `json:"name"`
+	Ready   string   `json:"ready"`
+	Drift   int64    `json:"drift"`
+	Silence string   `json:"silence,omitempty"`
+	Reasons []string `json:"reasons"`
+}
+
+func runReport(ctx context.Context, env *Env, args []string) error {
+	fs := newFlagSet(env, "report", commands["report"].Usage)
+	namespace := fs.String("n", "", "only scan this namespace (defaults to all namespaces)")
+	fs.StringVar(namespace, "namespace", "", "same as -n")
+	silence := fs.Duration("silence", k8s.DefaultReportSilence, "report reconciles without status update for longer than this, 0 disables the check")
+	concurrency := fs.Int("concurrency", k8s.DefaultReportConcurrency, "number of CRDs scanned in parallel")
+	pageSize := fs.Int64("page-size", k8s.DefaultPageSize, "number of resources fetched per request")
+	output := fs.String("o", "markdown", "output format: markdown, html or json")
+	fs.StringVar(output, "output", "markdown", "same as -o")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		fs.Usage()
+		return &ExitError{Code: 2}
+	}
+
+	client, err := env.client()
+	if err != nil {
+		return err
+	}
+	report, err := client.Report().Scan(ctx, k8s.ReportOptions{
+		Namespace:    *namespace,
+		Concurrency:  *concurrency,
+		PageSize:     *pageSize,
+		SilenceAfter: *silence,
+	})
+	if err != nil {
+		return err
+	}
+	out := newReportOutput(report)
+
+	switch *output {
+	case "markdown", "md":
+		return writeMarkdownReport(env.Stdout, out)
+	case "html":
+		return reportTemplate.Execute(env.Stdout, out)
+	case outputJSON:
+		return printOutput(env.Stdout, outputJSON, out, nil)
+	default:
+		return &ExitError{Code: 2, Err: fmt.Errorf("unknown output format %q, use markdown, html or json", *output)}
+	}
+}
+
+// newReportOutput converts a report into its printed form
+func newReportOutput(report *k8s.Report) reportOutput {
+	out := reportOutput{
+		GeneratedAt:      report.GeneratedAt,
+		Namespace:        report.Namespace,
+		ScannedCRDs:      report.ScannedCRDs,
+		ScannedResources: report.ScannedResources,
+		Unhealthy:        len(report.Resources),
+		Failed:           report.Failed,
+		Groups:           []reportGroupOutput{},
+	}
+	for _, g := range report.Groups() {
+		group := reportGroupOutput{CRD: g.CRD, Namespace: g.Namespace, Controller: g.Controller}
+		for _, u := range g.Resources {
+			r := reportResourceOutput{
+				Name:    u.Resource.Name,
+				Ready:   u.Resource.ReadyStatus(),
+				Drift:   u.Resource.Drift(),
+				Reasons: u.Reasons,
+			}
+			if !u.Resource.LastStatusWrite.IsZero() {
+				r.Silence = report.GeneratedAt.Sub(u.Resource.LastStatusWrite).Round(time.Second).String()
+			}
+			group.Resources = append(group.Resources, r)
+		}
+		out.Groups = append(out.Groups, group)
+	}
+	return out
+}
+
+// Scope describes the scanned namespaces for humans
+func (r reportOutput) Scope() string {
+	if r.Namespace == "" {
+		return "all namespaces"
+	}
+	return "namespace " + r.Namespace
+}
+
+// FailedCRDs returns the names of the CRDs that could not be scanned, sorted
+func (r reportOutput) FailedCRDs() []string {
+	names := make([]string, 0, len(r.Failed))
+	for name := range r.Failed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// writeMarkdownReport prints the report as Markdown with a section per CRD and a table per group
+func writeMarkdownReport(w io.Writer, r reportOutput) error {
+	var sb strings.Builder
+	sb.WriteString("# Unhealthy Custom Resources\n\n")
+	fmt.Fprintf(&sb, "Generated %s for %s. Scanned %d resources of %d CRDs, %d unhealthy.\n",
+		r.GeneratedAt.Format(time.RFC3339), r.Scope(), r.ScannedResources, r.ScannedCRDs, r.Unhealthy)
+
+	crd := ""
+	for _, g := range r.Groups {
+		if g.CRD != crd {
+			crd = g.CRD
+			fmt.Fprintf(&sb, "\n## %s\n", crd)
+		}
+		fmt.Fprintf(&sb, "\n### Namespace `%s`, controller `%s`\n\n", orDash(g.Namespace), orDash(g.Controller))
+		sb.WriteString("| Resource | Ready | Drift | Silence | Reasons |\n")
+		sb.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, res := range g.Resources {
+			fmt.Fprintf(&sb, "| %s | %s | %d | %s | %s |\n", markdownCell(res.Name), res.Ready, res.Drift,
+				orDash(res.Silence), markdownCell(strings.Join(res.Reasons, ", ")))
+		}
+	}
+
+	if len(r.Failed) > 0 {
+		sb.WriteString("\n## CRDs that could not be scanned\n\n")
+		for _, name := range r.FailedCRDs() {
+			fmt.Fprintf(&sb, "- `%s`: %s\n", name, markdownCell(r.Failed[name]))
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// markdownCell escapes pipes and line breaks that would break a table row
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"orDash": orDash,
+	"join":   strings.Join,
+	"rfc3339": func(t time.Time) string {
+		return t.Format(time.RFC3339)
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Unhealthy Custom Resources</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+th { background: #eee; }
+</style>
+</head>
+<body>
+<h1>Unhealthy Custom Resources</h1>
+<p>Generated {{rfc3339 .GeneratedAt}} for {{.Scope}}. Scanned {{.ScannedResources}} resources of {{.ScannedCRDs}} CRDs, {{.Unhealthy}} unhealthy.</p>
+{{- $crd := ""}}
+{{- range .Groups}}
+{{- if ne .CRD $crd}}{{$crd = .CRD}}
+<h2>{{.CRD}}</h2>
+{{- end}}
+<h3>Namespace <code>{{orDash .Namespace}}</code>, controller <code>{{orDash .Controller}}</code></h3>
+<table>
+<tr><th>Resource</th><th>Ready</th><th>Drift</th><th>Silence</th><th>Reasons</th></tr>
+{{- range .Resources}}
+<tr><td>{{.Name}}</td><td>{{.Ready}}</td><td>{{.Drift}}</td><td>{{orDash .Silence}}</td><td>{{join .Reasons ", "}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Failed}}
+<h2>CRDs that could not be scanned</h2>
+<ul>
+{{- range $name := .FailedCRDs}}
+<li><code>{{$name}}</code>: {{index $.Failed $name}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</body>
+</html>
+`))
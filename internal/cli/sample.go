@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pteich/crdlens/internal/k8s"
+	"github.com/pteich/crdlens/internal/ui/views"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func init() {
+	register(Command{
+		Name:    "sample",
+		Usage:   "<crd> [--version v1] [--full] [--file crds.yaml]",
+		Summary: "Print a sample manifest generated from a CRD schema",
+		Run:     runSample,
+	})
+}
+
+func runSample(ctx context.Context, env *Env, args []string) error {
+	fs := newFlagSet(env, "sample", commands["sample"].Usage)
+	version := fs.String("version", "", "CRD version to use (defaults to the storage version)")
+	full := fs.Bool("full", false, "include optional fields, not only required ones")
+	file := fs.String("file", "", "read the CRD from a file or directory instead of the cluster")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return &ExitError{Code: 2}
+	}
+
+	crd, err := findCRD(ctx, env, positional[0], *file)
+	if err != nil {
+		return err
+	}
+
+	sample, err := views.GenerateSample(crd, *version, *full)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(env.Stdout, sample)
+	return err
+}
+
+// findCRD looks up a CRD in the cluster or, if file is set, in the given manifests
+func findCRD(ctx context.Context, env *Env, name, file string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	if file == "" {
+		client, err := env.client()
+		if err != nil {
+			return nil, err
+		}
+		return client.Discovery().GetCRD(ctx, name)
+	}
+
+	crds, err := k8s.LoadCRDs(file)
+	if err != nil {
+		return nil, err
+	}
+	crd, err := k8s.FindCRD(crds, name)
+	if err != nil {
+		return nil, err
+	}
+	if crd == nil {
+		return nil, fmt.Errorf("no CRD %q found in %s", name, file)
+	}
+	return crd, nil
+}
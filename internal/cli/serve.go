@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/pteich/crdlens/internal/k8s"
+	"github.com/pteich/crdlens/internal/metrics"
+)
+
+func init() {
+	register(Command{
+		Name:    "serve",
+		Usage:   "--metrics :9090 [-n namespace] [--interval 30s] [--concurrency 4]",
+		Summary: "Run headless and export the health of all custom resources as Prometheus metrics",
+		Run:     runServe,
+	})
+}
+
+func runServe(ctx context.Context, env *Env, args []string) error {
+	fs := newFlagSet(env, "serve", commands["serve"].Usage)
+	addr := fs.String("metrics", "", "address to serve /metrics on, e.g. :9090")
+	namespace := fs.String("n", "", "only scan this namespace (defaults to all namespaces)")
+	fs.StringVar(namespace, "namespace", "", "same as -n")
+	interval := fs.Duration("interval", env.Config.RefreshInterval, "time between two scans of all CRDs")
+	concurrency := fs.Int("concurrency", k8s.DefaultReportConcurrency, "number of CRDs scanned in parallel")
+	pageSize := fs.Int64("page-size", k8s.DefaultPageSize, "number of resources fetched per request")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *addr == "" || len(positional) > 0 || *interval <= 0 {
+		fs.Usage()
+		return &ExitError{Code: 2}
+	}
+
+	client, err := env.client()
+	if err != nil {
+		return err
+	}
+
+	collector := metrics.NewCollector(client, metrics.Options{
+		Namespace:   *namespace,
+		Concurrency: *concurrency,
+		PageSize:    *pageSize,
+		Reconcile:   env.Config.Reconcile,
+	})
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	server := &http.Server{Addr: *addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go collector.Run(ctx, *interval, func(err error) {
+		fmt.Fprintf(env.Stderr, "Scan failed: %v\n", err)
+	})
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(env.Stderr, "Serving metrics on %s/metrics, scanning every %v\n", *addr, *interval)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pteich/crdlens/internal/k8s"
+)
+
+func init() {
+	register(Command{
+		Name:    "status",
+		Usage:   "<crd> [-n namespace | -A] [-l selector] [-o table|json|yaml]",
+		Summary: "Summarize the health of the resources of a CRD, exits with 1 if any is unhealthy",
+		Run:     runStatus,
+	})
+}
+
+// statusReport is the output of the status command
+type statusReport struct {
+	CRD       string            `json:"crd"`
+	Namespace string            `json:"namespace,omitempty"`
+	Total     int               `json:"total"`
+	Ready     map[string]int    `json:"ready"`
+	States    map[string]int    `json:"reconcileStates"`
+	Unhealthy []resourceSummary `json:"unhealthy"`
+}
+
+func runStatus(ctx context.Context, env *Env, args []string) error {
+	fs := newFlagSet(env, "status", commands["status"].Usage)
+	ns := addNamespaceFlags(fs, env, true)
+	labelSelector := fs.String("l", "", "label selector, e.g. app=web")
+	fs.StringVar(labelSelector, "selector", "", "same as -l")
+	output := outputFlag(fs)
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return &ExitError{Code: 2}
+	}
+
+	lister, err := newResourceLister(ctx, env, positional[0])
+	if err != nil {
+		return err
+	}
+	namespace := ns.resolve(env, lister.client, lister.crd)
+	summaries, err := lister.list(ctx, namespace, k8s.ListResourcesOptions{LabelSelector: *labelSelector})
+	if err != nil {
+		return err
+	}
+
+	report := statusReport{
+		CRD:       lister.crd.Name,
+		Namespace: namespace,
+		Total:     len(summaries),
+		Ready:     make(map[string]int),
+		States:    make(map[string]int),
+		Unhealthy: []resourceSummary{},
+	}
+	for _, s := range summaries {
+		report.Ready[s.Ready]++
+		report.States[s.ReconcileState]++
+		if s.unhealthy() {
+			report.Unhealthy = append(report.Unhealthy, s)
+		}
+	}
+
+	err = printOutput(env.Stdout, *output, report, func(w io.Writer) {
+		scope := "all namespaces"
+		if namespace != "" {
+			scope = "namespace " + namespace
+		}
+		fmt.Fprintf(w, "%s in %s: %d resources\n\n", report.CRD, scope, report.Total)
+		fmt.Fprintf(w, "Ready:\t%s\n", formatCounts(report.Ready))
+		fmt.Fprintf(w, "Reconcile State:\t%s\n", formatCounts(report.States))
+
+		if len(report.Unhealthy) == 0 {
+			return
+		}
+		fmt.Fprintln(w, "\nUNHEALTHY\tNAMESPACE\tREADY\tSTATE\tREASON")
+		for _, s := range report.Unhealthy {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", s.Name, orDash(s.Namespace), s.Ready, s.ReconcileState, s.Reason)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if len(report.Unhealthy) > 0 {
+		return &ExitError{Code: 1}
+	}
+	return nil
+}
+
+// formatCounts prints counts sorted by key, e.g. "NotReady=1 Ready=3"
+func formatCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "-"
+	}
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	text := ""
+	for i, k := range keys {
+		if i > 0 {
+			text += " "
+		}
+		text += fmt.Sprintf("%s=%d", k, counts[k])
+	}
+	return text
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pteich/crdlens/internal/k8s"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func init() {
+	register(Command{
+		Name:    "validate",
+		Usage:   "-f <file or dir> [-f ...] [--crds <file or dir>]",
+		Summary: "Validate custom resource manifests against the schemas of their CRDs",
+		Run:     runValidate,
+	})
+}
+
+// stringList is a flag that can be given multiple times
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// manifest is an object together with the file it was read from
+type manifest struct {
+	file string
+	obj  *unstructured.Unstructured
+}
+
+func runValidate(ctx context.Context, env *Env, args []string) error {
+	fs := newFlagSet(env, "validate", commands["validate"].Usage)
+	var files stringList
+	fs.Var(&files, "f", "manifest file or directory to validate, can be repeated")
+	fs.Var(&files, "filename", "same as -f")
+	crdPath := fs.String("crds", "", "read CRDs from a file or directory instead of the cluster")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 || len(positional) > 0 {
+		fs.Usage()
+		return &ExitError{Code: 2}
+	}
+
+	// CRDs shipped next to the resources are used as well
+	var manifests []manifest
+	var crds []*apiextensionsv1.CustomResourceDefinition
+	for _, path := range files {
+		paths, err := k8s.ManifestFiles(path)
+		if err != nil {
+			return err
+		}
+		for _, file := range paths {
+			objects, err := k8s.ReadManifestFile(file)
+			if err != nil {
+				return err
+			}
+			for _, obj := range objects {
+				if obj.GetKind() == "CustomResourceDefinition" && obj.GroupVersionKind().Group == apiextensionsv1.GroupName {
+					crd, err := k8s.ToCRD(obj)
+					if err != nil {
+						return fmt.Errorf("invalid CRD %s in %s: %w", obj.GetName(), file, err)
+					}
+					crds = append(crds, crd)
+					continue
+				}
+				manifests = append(manifests, manifest{file: file, obj: obj})
+			}
+		}
+	}
+
+	if *crdPath != "" {
+		fileCRDs, err := k8s.LoadCRDs(*crdPath)
+		if err != nil {
+			return err
+		}
+		crds = append(crds, fileCRDs...)
+	} else {
+		client, err := env.client()
+		if err != nil {
+			return err
+		}
+		clusterCRDs, err := client.Discovery().ListCRDObjects(ctx)
+		if err != nil {
+			return err
+		}
+		// Local CRDs take precedence, they may be newer than the installed ones
+		crds = append(clusterCRDs, crds...)
+	}
+
+	validator := k8s.NewSchemaValidator(crds)
+	validated, skipped, violations := 0, 0, 0
+	for _, m := range manifests {
+		if !validator.Knows(m.obj) {
+			// Built-in kinds and resources of unknown CRDs
+			skipped++
+			continue
+		}
+		validated++
+
+		result, err := validator.Validate(ctx, m.obj)
+		if err != nil {
+			return fmt.Errorf("%s: %w", m.file, err)
+		}
+		for _, v := range result {
+			v.File = m.file
+			fmt.Fprintln(env.Stdout, v)
+		}
+		violations += len(result)
+	}
+
+	fmt.Fprintf(env.Stderr, "%d resources validated, %d skipped without CRD, %d violations\n", validated, skipped, violations)
+	if violations > 0 {
+		return &ExitError{Code: 1}
+	}
+	return nil
+}
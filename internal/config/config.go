@@ -2,6 +2,8 @@ package config
 
 import (
 	"time"
+
+	"github.com/pteich/crdlens/internal/types"
 )
 
 // Config represents the tool's configuration
@@ -15,6 +17,79 @@ type Config struct {
 	Keybindings     KeybindingsConfig `yaml:"keybindings"`
 	CacheSize       int               `yaml:"cacheSize"`
 	DisableCounts   bool              `yaml:"disableCounts"`
+	// Annotation patched to request a reconcile from controllers without a known convention
+	ReconcileAnnotation string `yaml:"reconcileAnnotation"`
+	// Thresholds for stuck reconcile detection
+	Reconcile ReconcileConfig `yaml:"reconcile"`
+	// Health rules by GroupKind, e.g. Certificate.cert-manager.io, see HealthRules
+	Health map[string]types.HealthRule `yaml:"health"`
+	// StatusEngine derives the ready status of resources without health rule, builtin or kstatus
+	StatusEngine string `yaml:"statusEngine"`
+	// Starred CRDs and resources by kubeconfig context
+	Favorites map[string]Favorites `yaml:"favorites,omitempty"`
+	// Path of the config file favorites are saved to, empty disables saving
+	Path string `yaml:"-"`
+	// Load CRDs and CRs from YAML files instead of a cluster, only set by flags
+	FromDir  string `yaml:"-"`
+	FromFile string `yaml:"-"`
+}
+
+// OfflineSources returns the files and directories to load resources from,
+// it is empty when a cluster should be used
+func (c *Config) OfflineSources() []string {
+	var sources []string
+	if c.FromDir != "" {
+		sources = append(sources, c.FromDir)
+	}
+	if c.FromFile != "" {
+		sources = append(sources, c.FromFile)
+	}
+	return sources
+}
+
+// Status engines for resources without health rule
+const (
+	// StatusEngineBuiltin checks common conditions, Argo CD health and phases
+	StatusEngineBuiltin = "builtin"
+	// StatusEngineKStatus uses kstatus from cli-utils like kubectl and kpt
+	StatusEngineKStatus = "kstatus"
+)
+
+// ReconcileConfig holds the default reconcile thresholds and overrides for
+// specific CRDs and controllers. Overrides only replace the values they set.
+type ReconcileConfig struct {
+	types.ReconcileThresholds `yaml:",inline"`
+	// CRDs overrides thresholds by CRD name, e.g. clusters.cluster.x-k8s.io
+	CRDs map[string]types.ReconcileThresholds `yaml:"crds"`
+	// Controllers overrides thresholds by field manager, e.g. helm-controller
+	Controllers map[string]types.ReconcileThresholds `yaml:"controllers"`
+}
+
+// ThresholdsFor returns the thresholds for resources of a CRD managed by a controller.
+// CRD overrides take precedence over controller overrides.
+func (c ReconcileConfig) ThresholdsFor(crd, controller string) types.ReconcileThresholds {
+	th := c.ReconcileThresholds
+	if o, ok := c.Controllers[controller]; ok {
+		th = overrideThresholds(th, o)
+	}
+	if o, ok := c.CRDs[crd]; ok {
+		th = overrideThresholds(th, o)
+	}
+	return th
+}
+
+// overrideThresholds replaces the values of th that are set in o
+func overrideThresholds(th, o types.ReconcileThresholds) types.ReconcileThresholds {
+	if o.StuckAfter != 0 {
+		th.StuckAfter = o.StuckAfter
+	}
+	if o.SilenceAfter != 0 {
+		th.SilenceAfter = o.SilenceAfter
+	}
+	if o.WarningEvents != 0 {
+		th.WarningEvents = o.WarningEvents
+	}
+	return th
 }
 
 // ThemeConfig defines the appearance of the TUI
@@ -37,9 +112,18 @@ type KeybindingsConfig struct {
 // DefaultConfig returns a config with sensible defaults
 func DefaultConfig() *Config {
 	return &Config{
-		RefreshInterval: 30 * time.Second,
-		CacheSize:       1000,
-		DisableCounts:   true,
+		RefreshInterval:     30 * time.Second,
+		CacheSize:           1000,
+		DisableCounts:       true,
+		ReconcileAnnotation: "crdlens.io/reconcile-requested-at",
+		Reconcile: ReconcileConfig{
+			ReconcileThresholds: types.ReconcileThresholds{
+				StuckAfter:    15 * time.Minute,
+				SilenceAfter:  5 * time.Minute,
+				WarningEvents: 3,
+			},
+		},
+		StatusEngine: StatusEngineBuiltin,
 		Theme: ThemeConfig{
 			Primary:   "#7D56F4",
 			Secondary: "#F780E2",
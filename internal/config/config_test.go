@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,6 +17,7 @@ func TestDefaultConfig(t *testing.T) {
 	assert.NotZero(t, cfg.RefreshInterval, "refresh interval should be set")
 	assert.Equal(t, 1000, cfg.CacheSize)
 	assert.True(t, cfg.DisableCounts)
+	assert.Equal(t, "crdlens.io/reconcile-requested-at", cfg.ReconcileAnnotation)
 	assert.Equal(t, "#7D56F4", cfg.Theme.Primary)
 	assert.Equal(t, "q", cfg.Keybindings.Quit)
 }
@@ -44,3 +46,123 @@ theme:
 	assert.Equal(t, "test-ns", cfg.Namespace)
 	assert.Equal(t, "#112233", cfg.Theme.Primary)
 }
+
+func TestReconcileConfig_ThresholdsFor(t *testing.T) {
+	yamlContent := `
+reconcile:
+  stuckAfter: 10m
+  controllers:
+    helm-controller:
+      stuckAfter: 20m
+      warningEvents: 5
+  crds:
+    clusters.cluster.x-k8s.io:
+      stuckAfter: 1h
+`
+	cfg := DefaultConfig()
+	require.NoError(t, yaml.Unmarshal([]byte(yamlContent), cfg))
+
+	th := cfg.Reconcile.ThresholdsFor("widgets.example.com", "widget-operator")
+	assert.Equal(t, 10*time.Minute, th.StuckAfter)
+	assert.Equal(t, 5*time.Minute, th.SilenceAfter, "defaults are kept")
+	assert.Equal(t, 3, th.WarningEvents)
+
+	th = cfg.Reconcile.ThresholdsFor("releases.helm.example.com", "helm-controller")
+	assert.Equal(t, 20*time.Minute, th.StuckAfter)
+	assert.Equal(t, 5, th.WarningEvents)
+
+	// CRD overrides win over controller overrides
+	th = cfg.Reconcile.ThresholdsFor("clusters.cluster.x-k8s.io", "helm-controller")
+	assert.Equal(t, time.Hour, th.StuckAfter)
+	assert.Equal(t, 5, th.WarningEvents)
+}
+
+func TestConfig_Favorites(t *testing.T) {
+	cfg := DefaultConfig()
+
+	assert.True(t, cfg.ToggleFavoriteCRD("prod", "widgets.example.com"))
+	assert.True(t, cfg.ToggleFavoriteResource("prod", "widgets.example.com", "default", "a"))
+	assert.True(t, cfg.IsFavoriteCRD("prod", "widgets.example.com"))
+	assert.False(t, cfg.IsFavoriteCRD("dev", "widgets.example.com"), "favorites are per context")
+	assert.True(t, cfg.IsFavoriteResource("prod", "widgets.example.com", "default", "a"))
+	assert.False(t, cfg.IsFavoriteResource("prod", "widgets.example.com", "other", "a"))
+
+	assert.False(t, cfg.ToggleFavoriteCRD("prod", "widgets.example.com"))
+	assert.False(t, cfg.ToggleFavoriteResource("prod", "widgets.example.com", "default", "a"))
+	assert.Empty(t, cfg.Favorites, "contexts without favorites are dropped")
+}
+
+func TestConfig_SaveFavorites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".crdlens.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`# my settings
+namespace: test-ns # keep me
+favorites:
+  old:
+    crds: [gone.example.com]
+`), 0o600))
+
+	cfg := DefaultConfig()
+	cfg.Path = path
+	cfg.ToggleFavoriteCRD("prod", "widgets.example.com")
+	cfg.ToggleFavoriteResource("prod", "widgets.example.com", "", "cluster-wide")
+	require.NoError(t, cfg.SaveFavorites())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# my settings")
+	assert.Contains(t, string(data), "namespace: test-ns # keep me")
+	assert.NotContains(t, string(data), "gone.example.com")
+
+	loaded := DefaultConfig()
+	require.NoError(t, yaml.Unmarshal(data, loaded))
+	assert.Equal(t, "test-ns", loaded.Namespace)
+	assert.Equal(t, map[string]Favorites{"prod": {
+		CRDs:      []string{"widgets.example.com"},
+		Resources: []string{"widgets.example.com//cluster-wide"},
+	}}, loaded.Favorites)
+
+	// Removing the last favorite removes the key
+	cfg.ToggleFavoriteCRD("prod", "widgets.example.com")
+	cfg.ToggleFavoriteResource("prod", "widgets.example.com", "", "cluster-wide")
+	require.NoError(t, cfg.SaveFavorites())
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "favorites")
+}
+
+func TestConfig_SaveFavorites_NewFile(t *testing.T) {
+	cfg := DefaultConfig()
+	require.NoError(t, cfg.SaveFavorites(), "nothing is saved without path")
+
+	cfg.Path = filepath.Join(t.TempDir(), ".crdlens.yaml")
+	cfg.ToggleFavoriteCRD("prod", "widgets.example.com")
+	require.NoError(t, cfg.SaveFavorites())
+
+	data, err := os.ReadFile(cfg.Path)
+	require.NoError(t, err)
+	loaded := DefaultConfig()
+	require.NoError(t, yaml.Unmarshal(data, loaded))
+	assert.True(t, loaded.IsFavoriteCRD("prod", "widgets.example.com"))
+}
+
+func TestConfigLoad_HealthRules(t *testing.T) {
+	yamlContent := `
+health:
+  Certificate.cert-manager.io:
+    readyConditions: [Ready]
+  Widget.example.com:
+    field: .status.phase
+    ready: [Up]
+`
+	cfg := DefaultConfig()
+	require.NoError(t, yaml.Unmarshal([]byte(yamlContent), cfg))
+
+	rules := cfg.HealthRules()
+	assert.Equal(t, []string{"Up"}, rules["Widget.example.com"].Ready)
+	assert.Empty(t, rules["Certificate.cert-manager.io"].ProgressingConditions, "rules replace the built-in rule")
+	assert.Contains(t, rules, "Application.argoproj.io", "other built-in rules are kept")
+
+	// kstatus replaces the built-in rules, only the rules of the config file are used
+	cfg.StatusEngine = StatusEngineKStatus
+	assert.Equal(t, cfg.Health, cfg.HealthRules())
+}
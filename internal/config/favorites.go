@@ -0,0 +1,130 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Favorites are the starred CRDs and resources of a kubeconfig context
+type Favorites struct {
+	CRDs      []string `yaml:"crds,omitempty"`      // CRD names, e.g. certificates.cert-manager.io
+	Resources []string `yaml:"resources,omitempty"` // Keys built by ResourceKey
+}
+
+// ResourceKey identifies a resource of a CRD in the favorites, namespace is empty for cluster-scoped resources
+func ResourceKey(crd, namespace, name string) string {
+	return crd + "/" + namespace + "/" + name
+}
+
+// IsFavoriteCRD returns true if the CRD is starred in the context
+func (c *Config) IsFavoriteCRD(context, crd string) bool {
+	return slices.Contains(c.Favorites[context].CRDs, crd)
+}
+
+// ToggleFavoriteCRD stars or unstars the CRD in the context and returns whether it is starred now
+func (c *Config) ToggleFavoriteCRD(context, crd string) bool {
+	fav := c.Favorites[context]
+	var starred bool
+	fav.CRDs, starred = toggle(fav.CRDs, crd)
+	c.setFavorites(context, fav)
+	return starred
+}
+
+// IsFavoriteResource returns true if the resource is starred in the context
+func (c *Config) IsFavoriteResource(context, crd, namespace, name string) bool {
+	return slices.Contains(c.Favorites[context].Resources, ResourceKey(crd, namespace, name))
+}
+
+// ToggleFavoriteResource stars or unstars the resource in the context and returns whether it is starred now
+func (c *Config) ToggleFavoriteResource(context, crd, namespace, name string) bool {
+	fav := c.Favorites[context]
+	var starred bool
+	fav.Resources, starred = toggle(fav.Resources, ResourceKey(crd, namespace, name))
+	c.setFavorites(context, fav)
+	return starred
+}
+
+// setFavorites stores the favorites of a context and drops contexts without any
+func (c *Config) setFavorites(context string, fav Favorites) {
+	if len(fav.CRDs) == 0 && len(fav.Resources) == 0 {
+		delete(c.Favorites, context)
+		return
+	}
+	if c.Favorites == nil {
+		c.Favorites = make(map[string]Favorites)
+	}
+	c.Favorites[context] = fav
+}
+
+// toggle removes value from the list if present, otherwise adds it and sorts the list
+func toggle(list []string, value string) ([]string, bool) {
+	if i := slices.Index(list, value); i >= 0 {
+		return slices.Delete(slices.Clone(list), i, i+1), false
+	}
+	list = append(slices.Clone(list), value)
+	slices.Sort(list)
+	return list, true
+}
+
+// SaveFavorites writes the favorites to the config file at Path. Only the favorites
+// key is replaced, the rest of the file including comments is kept. Without Path
+// nothing is saved.
+func (c *Config) SaveFavorites() error {
+	if c.Path == "" {
+		return nil
+	}
+
+	var doc yaml.Node
+	data, err := os.ReadFile(c.Path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to update config: %s is not a mapping", c.Path)
+	}
+
+	var value yaml.Node
+	if err := value.Encode(c.Favorites); err != nil {
+		return fmt.Errorf("failed to encode favorites: %w", err)
+	}
+	setMappingValue(root, "favorites", &value, len(c.Favorites) == 0)
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := os.WriteFile(c.Path, out, 0o600); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// setMappingValue replaces the value of key in a mapping node, appends it if missing
+// or removes the key if remove is set
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node, remove bool) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != key {
+			continue
+		}
+		if remove {
+			mapping.Content = slices.Delete(mapping.Content, i, i+2)
+		} else {
+			mapping.Content[i+1] = value
+		}
+		return
+	}
+	if !remove {
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"maps"
+
+	"github.com/pteich/crdlens/internal/types"
+)
+
+// fluxRule is shared by all Flux toolkit kinds
+var fluxRule = types.HealthRule{
+	ReadyConditions:       []string{"Ready"},
+	NotReadyConditions:    []string{"Stalled"},
+	ProgressingConditions: []string{"Reconciling"},
+}
+
+// DefaultHealthRules returns the built-in health rules for common operators by GroupKind.
+// Keys are Kind.group, or *.group for all kinds of a group. Rules in the config file
+// replace the built-in rule of the same key.
+func DefaultHealthRules() map[string]types.HealthRule {
+	return map[string]types.HealthRule{
+		"*.kustomize.toolkit.fluxcd.io":    fluxRule,
+		"*.helm.toolkit.fluxcd.io":         fluxRule,
+		"*.source.toolkit.fluxcd.io":       fluxRule,
+		"*.notification.toolkit.fluxcd.io": fluxRule,
+		"*.image.toolkit.fluxcd.io":        fluxRule,
+		"Application.argoproj.io": {
+			Field:       ".status.health.status",
+			Ready:       []string{"Healthy"},
+			NotReady:    []string{"Degraded", "Missing", "Unknown"},
+			Progressing: []string{"Progressing", "Suspended"},
+		},
+		"Rollout.argoproj.io": {
+			Field:       ".status.phase",
+			Ready:       []string{"Healthy"},
+			NotReady:    []string{"Degraded"},
+			Progressing: []string{"Progressing", "Paused"},
+		},
+		"Certificate.cert-manager.io": {
+			ReadyConditions:       []string{"Ready"},
+			ProgressingConditions: []string{"Issuing"},
+		},
+		"Issuer.cert-manager.io":        {ReadyConditions: []string{"Ready"}},
+		"ClusterIssuer.cert-manager.io": {ReadyConditions: []string{"Ready"}},
+		"Cluster.cluster.x-k8s.io": {
+			ReadyConditions: []string{"Ready"},
+			Field:           ".status.phase",
+			Ready:           []string{"Provisioned"},
+			NotReady:        []string{"Failed"},
+			Progressing:     []string{"Pending", "Provisioning", "Deleting"},
+		},
+		"Machine.cluster.x-k8s.io": {
+			ReadyConditions: []string{"Ready"},
+			Field:           ".status.phase",
+			Ready:           []string{"Running"},
+			NotReady:        []string{"Failed"},
+			Progressing:     []string{"Pending", "Provisioning", "Provisioned", "Deleting"},
+		},
+		"PipelineRun.tekton.dev": tektonRunRule,
+		"TaskRun.tekton.dev":     tektonRunRule,
+	}
+}
+
+// HealthRules returns the rules to evaluate: the built-in rules merged with the rules of
+// the config file. With the kstatus engine only the rules of the config file are used,
+// kstatus covers the operators of the built-in rules.
+func (c *Config) HealthRules() map[string]types.HealthRule {
+	rules := make(map[string]types.HealthRule)
+	if c.StatusEngine != StatusEngineKStatus {
+		maps.Copy(rules, DefaultHealthRules())
+	}
+	maps.Copy(rules, c.Health)
+	return rules
+}
+
+// tektonRunRule treats runs as Progressing while Succeeded is Unknown
+var tektonRunRule = types.HealthRule{
+	ReadyConditions: []string{"Succeeded"},
+	Expressions: types.HealthExpressions{
+		Progressing: "self.status.conditions.exists(c, c.type == 'Succeeded' && c.status == 'Unknown')",
+	},
+}
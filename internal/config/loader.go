@@ -16,6 +16,7 @@ func Load() (*Config, error) {
 	home, err := os.UserHomeDir()
 	if err == nil {
 		configPath := filepath.Join(home, ".crdlens.yaml")
+		cfg.Path = configPath
 		if _, err := os.Stat(configPath); err == nil {
 			data, err := os.ReadFile(configPath)
 			if err == nil {
@@ -30,6 +31,8 @@ func Load() (*Config, error) {
 	namespace := flag.String("namespace", "", "the namespace to use")
 	allNamespaces := flag.Bool("all-namespaces", cfg.AllNamespaces, "list resources in all namespaces")
 	enableCounts := flag.Bool("enable-counts", !cfg.DisableCounts, "enable CR counts in the CRD list")
+	fromDir := flag.String("from-dir", "", "load CRDs and CRs from YAML files in a directory instead of a cluster")
+	fromFile := flag.String("from-file", "", "load CRDs and CRs from a YAML file instead of a cluster")
 
 	flag.Parse()
 
@@ -49,6 +52,8 @@ func Load() (*Config, error) {
 	if *enableCounts {
 		cfg.DisableCounts = false
 	}
+	cfg.FromDir = *fromDir
+	cfg.FromFile = *fromFile
 
 	return cfg, nil
 }
@@ -24,10 +24,20 @@ type Client struct {
 	Config              *rest.Config
 	Context             string
 	Namespace           string
+	// ReadOnly is set for offline clients, write actions are disabled
+	ReadOnly bool
+	// Health derives the ready status of resources from the configured rules
+	Health *HealthRules
+	// StatusEngine derives the ready status of resources without health rule
+	StatusEngine string
 }
 
 // NewClient initializes Kubernetes clients based on the provided configuration
 func NewClient(cfg *config.Config) (*Client, error) {
+	if sources := cfg.OfflineSources(); len(sources) > 0 {
+		return NewOfflineClient(cfg, sources)
+	}
+
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 	if cfg.Kubeconfig != "" {
 		loadingRules.ExplicitPath = cfg.Kubeconfig
@@ -38,6 +48,14 @@ func NewClient(cfg *config.Config) (*Client, error) {
 		configOverrides.CurrentContext = cfg.Context
 	}
 
+	health, err := NewHealthRules(cfg.HealthRules())
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStatusEngine(cfg.StatusEngine); err != nil {
+		return nil, err
+	}
+
 	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
 
 	restConfig, err := clientConfig.ClientConfig()
@@ -93,6 +111,8 @@ func NewClient(cfg *config.Config) (*Client, error) {
 		Config:              restConfig,
 		Context:             currentContext,
 		Namespace:           cfg.Namespace,
+		Health:              health,
+		StatusEngine:        cfg.StatusEngine,
 	}, nil
 }
 
@@ -117,7 +137,20 @@ func (c *Client) Discovery() *DiscoveryService {
 
 // Dynamic returns a new DynamicService
 func (c *Client) Dynamic() *DynamicService {
-	return NewDynamicService(c.DynamicClient)
+	svc := NewDynamicService(c.DynamicClient)
+	svc.health = c.Health
+	svc.statusEngine = c.StatusEngine
+	return svc
+}
+
+// Owners returns a new OwnerService
+func (c *Client) Owners() *OwnerService {
+	return NewOwnerService(c.Discovery(), c.Dynamic())
+}
+
+// Report returns a new ReportService
+func (c *Client) Report() *ReportService {
+	return NewReportService(c.Discovery(), c.Dynamic())
 }
 
 // Events returns a new EventService
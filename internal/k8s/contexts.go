@@ -0,0 +1,49 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/pteich/crdlens/internal/config"
+)
+
+// ContextInfo describes a context of the kubeconfig
+type ContextInfo struct {
+	Name      string
+	Cluster   string
+	User      string
+	Namespace string // Default namespace of the context, empty if not set
+	Current   bool   // current-context of the kubeconfig
+}
+
+// ListContexts returns the contexts of the kubeconfig used by cfg, sorted by name
+func ListContexts(cfg *config.Config) ([]ContextInfo, error) {
+	if len(cfg.OfflineSources()) > 0 {
+		return nil, errors.New("contexts are not available in offline mode")
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if cfg.Kubeconfig != "" {
+		loadingRules.ExplicitPath = cfg.Kubeconfig
+	}
+	rawConfig, err := loadingRules.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	contexts := make([]ContextInfo, 0, len(rawConfig.Contexts))
+	for name, ctx := range rawConfig.Contexts {
+		contexts = append(contexts, ContextInfo{
+			Name:      name,
+			Cluster:   ctx.Cluster,
+			User:      ctx.AuthInfo,
+			Namespace: ctx.Namespace,
+			Current:   name == rawConfig.CurrentContext,
+		})
+	}
+	sort.Slice(contexts, func(i, j int) bool { return contexts[i].Name < contexts[j].Name })
+	return contexts, nil
+}
@@ -0,0 +1,63 @@
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pteich/crdlens/internal/config"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: prod
+clusters:
+  - name: prod-cluster
+    cluster:
+      server: https://prod.example.com
+  - name: dev-cluster
+    cluster:
+      server: https://dev.example.com
+users:
+  - name: admin
+    user:
+      token: secret
+contexts:
+  - name: prod
+    context:
+      cluster: prod-cluster
+      user: admin
+  - name: dev
+    context:
+      cluster: dev-cluster
+      user: admin
+      namespace: team-a
+`
+
+func TestListContexts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte(testKubeconfig), 0o600))
+
+	cfg := config.DefaultConfig()
+	cfg.Kubeconfig = path
+	contexts, err := ListContexts(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []ContextInfo{
+		{Name: "dev", Cluster: "dev-cluster", User: "admin", Namespace: "team-a"},
+		{Name: "prod", Cluster: "prod-cluster", User: "admin", Current: true},
+	}, contexts)
+
+	// The client of another context uses its default namespace
+	cfg.Context = "dev"
+	client, err := NewClient(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "dev", client.Context)
+	assert.Equal(t, "team-a", client.Namespace)
+
+	cfg.FromDir = t.TempDir()
+	_, err = ListContexts(cfg)
+	assert.ErrorContains(t, err, "offline mode")
+}
@@ -3,9 +3,12 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/pteich/crdlens/internal/types"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -30,40 +33,168 @@ func (s *DiscoveryService) ListCRDs(ctx context.Context) ([]types.CRDInfo, error
 	}
 
 	var crds []types.CRDInfo
-	for _, crd := range crdList.Items {
-		// Find the served version that is marked as storage
-		var version string
-		for _, v := range crd.Spec.Versions {
-			if v.Served {
-				version = v.Name
-				if v.Storage {
-					break
-				}
-			}
+	for i := range crdList.Items {
+		if info, ok := CRDInfoFor(&crdList.Items[i]); ok {
+			crds = append(crds, info)
 		}
+	}
 
-		if version == "" {
-			continue
-		}
+	return crds, nil
+}
+
+// GetCRD fetches a CRD by its full name. Plural, singular, kind and short names
+// are accepted as well as long as they are unambiguous.
+func (s *DiscoveryService) GetCRD(ctx context.Context, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	crd, err := s.client.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+	if err == nil || !apierrors.IsNotFound(err) {
+		return crd, err
+	}
+
+	crdList, listErr := s.client.ApiextensionsV1().CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+	if listErr != nil {
+		return nil, fmt.Errorf("failed to list crds: %w", listErr)
+	}
+
+	crds := make([]*apiextensionsv1.CustomResourceDefinition, len(crdList.Items))
+	for i := range crdList.Items {
+		crds[i] = &crdList.Items[i]
+	}
+	crd, findErr := FindCRD(crds, name)
+	if crd == nil && findErr == nil {
+		return nil, err
+	}
+	return crd, findErr
+}
 
-		scope := "Namespaced"
-		if crd.Spec.Scope == "Cluster" {
-			scope = "Cluster"
+// FindCRD looks up a CRD by its full name, plural, singular, kind or short names, ignoring
+// case. It returns nil without a match and an error if the name is ambiguous.
+func FindCRD(crds []*apiextensionsv1.CustomResourceDefinition, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	var matches []*apiextensionsv1.CustomResourceDefinition
+	for _, crd := range crds {
+		if strings.EqualFold(crd.Name, name) {
+			return crd, nil
+		}
+		if crdMatchesName(crd, name) {
+			matches = append(matches, crd)
 		}
+	}
 
-		crds = append(crds, types.CRDInfo{
-			Name:    crd.Name,
-			Group:   crd.Spec.Group,
-			Version: version,
-			Kind:    crd.Spec.Names.Kind,
-			Scope:   scope,
-			GVR: schema.GroupVersionResource{
-				Group:    crd.Spec.Group,
-				Version:  version,
-				Resource: crd.Spec.Names.Plural,
-			},
-		})
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matches[0], nil
+	default:
+		names := make([]string, len(matches))
+		for i, m := range matches {
+			names[i] = m.Name
+		}
+		return nil, fmt.Errorf("%q is ambiguous, it matches %s", name, strings.Join(names, ", "))
 	}
+}
 
+// ListCRDObjects returns the full definitions of all CRDs in the cluster
+func (s *DiscoveryService) ListCRDObjects(ctx context.Context) ([]*apiextensionsv1.CustomResourceDefinition, error) {
+	crdList, err := s.client.ApiextensionsV1().CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list crds: %w", err)
+	}
+	crds := make([]*apiextensionsv1.CustomResourceDefinition, len(crdList.Items))
+	for i := range crdList.Items {
+		crds[i] = &crdList.Items[i]
+	}
 	return crds, nil
 }
+
+// CRDInfoFor converts a CRD into the metadata used to browse its resources.
+// It returns false if the CRD has no served version.
+func CRDInfoFor(crd *apiextensionsv1.CustomResourceDefinition) (types.CRDInfo, bool) {
+	// Find the served version that is marked as storage
+	var version string
+	for _, v := range crd.Spec.Versions {
+		if v.Served {
+			version = v.Name
+			if v.Storage {
+				break
+			}
+		}
+	}
+
+	if version == "" {
+		return types.CRDInfo{}, false
+	}
+
+	versions := make([]types.CRDVersion, 0, len(crd.Spec.Versions))
+	for _, v := range crd.Spec.Versions {
+		cv := types.CRDVersion{
+			Name:       v.Name,
+			Served:     v.Served,
+			Storage:    v.Storage,
+			Deprecated: v.Deprecated,
+		}
+		if v.DeprecationWarning != nil {
+			cv.DeprecationWarning = *v.DeprecationWarning
+		}
+		for _, col := range v.AdditionalPrinterColumns {
+			cv.PrinterColumns = append(cv.PrinterColumns, types.PrinterColumn{
+				Name:        col.Name,
+				Type:        col.Type,
+				Format:      col.Format,
+				Description: col.Description,
+				Priority:    col.Priority,
+				JSONPath:    col.JSONPath,
+			})
+		}
+		versions = append(versions, cv)
+	}
+
+	conversion := string(apiextensionsv1.NoneConverter)
+	if crd.Spec.Conversion != nil && crd.Spec.Conversion.Strategy != "" {
+		conversion = string(crd.Spec.Conversion.Strategy)
+	}
+
+	scope := "Namespaced"
+	if crd.Spec.Scope == "Cluster" {
+		scope = "Cluster"
+	}
+
+	return types.CRDInfo{
+		Name:    crd.Name,
+		Group:   crd.Spec.Group,
+		Version: version,
+		Kind:    crd.Spec.Names.Kind,
+		Scope:   scope,
+		GVR: schema.GroupVersionResource{
+			Group:    crd.Spec.Group,
+			Version:  version,
+			Resource: crd.Spec.Names.Plural,
+		},
+		Versions:   versions,
+		Conversion: conversion,
+	}, true
+}
+
+// GetCRDInfo fetches a CRD by name like GetCRD and returns its metadata
+func (s *DiscoveryService) GetCRDInfo(ctx context.Context, name string) (types.CRDInfo, error) {
+	crd, err := s.GetCRD(ctx, name)
+	if err != nil {
+		return types.CRDInfo{}, err
+	}
+	info, ok := CRDInfoFor(crd)
+	if !ok {
+		return types.CRDInfo{}, fmt.Errorf("CRD %s has no served version", crd.Name)
+	}
+	return info, nil
+}
+
+// crdMatchesName checks name against the names a CRD can be referred to by
+func crdMatchesName(crd *apiextensionsv1.CustomResourceDefinition, name string) bool {
+	names := crd.Spec.Names
+	candidates := append([]string{names.Plural, names.Singular, names.Kind}, names.ShortNames...)
+	for _, c := range candidates {
+		if c != "" && strings.EqualFold(c, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -47,3 +47,78 @@ func TestDiscoveryService_ListCRDs(t *testing.T) {
 	assert.Equal(t, "Namespaced", result.Scope)
 	assert.Equal(t, "certificates", result.GVR.Resource)
 }
+
+func TestDiscoveryService_ListCRDs_MultipleVersions(t *testing.T) {
+	warning := "example.com/v1beta1 Widget is deprecated; use example.com/v1"
+	crd := &v1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "widgets.example.com",
+		},
+		Spec: v1.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: v1.CustomResourceDefinitionNames{
+				Kind:   "Widget",
+				Plural: "widgets",
+			},
+			Scope: v1.ClusterScoped,
+			Versions: []v1.CustomResourceDefinitionVersion{
+				{Name: "v1beta1", Served: true, Deprecated: true, DeprecationWarning: &warning},
+				{Name: "v1", Served: true, Storage: true},
+				{Name: "v1alpha1", Served: false},
+			},
+			Conversion: &v1.CustomResourceConversion{Strategy: v1.WebhookConverter},
+		},
+	}
+
+	svc := NewDiscoveryService(fake.NewSimpleClientset(crd))
+	crds, err := svc.ListCRDs(context.Background())
+	require.NoError(t, err)
+	require.Len(t, crds, 1)
+
+	result := crds[0]
+	assert.Equal(t, "v1", result.Version, "storage version should be the default")
+	assert.Equal(t, "Webhook", result.Conversion)
+	require.Len(t, result.Versions, 3)
+	assert.True(t, result.Versions[0].Deprecated)
+	assert.Equal(t, warning, result.Versions[0].DeprecationWarning)
+	assert.Len(t, result.ServedVersions(), 2)
+
+	beta := result.WithVersion("v1beta1")
+	assert.Equal(t, "v1beta1", beta.GVR.Version)
+	assert.True(t, beta.CurrentVersion().Deprecated)
+}
+
+func TestDiscoveryService_GetCRD(t *testing.T) {
+	newCRD := func(name, group, kind, plural string) *v1.CustomResourceDefinition {
+		return &v1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1.CustomResourceDefinitionSpec{
+				Group: group,
+				Names: v1.CustomResourceDefinitionNames{Kind: kind, Plural: plural},
+			},
+		}
+	}
+	svc := NewDiscoveryService(fake.NewSimpleClientset(
+		newCRD("certificates.cert-manager.io", "cert-manager.io", "Certificate", "certificates"),
+		newCRD("issuers.cert-manager.io", "cert-manager.io", "Issuer", "issuers"),
+		newCRD("issuers.example.com", "example.com", "Issuer", "issuers"),
+	))
+	ctx := context.Background()
+
+	crd, err := svc.GetCRD(ctx, "certificates.cert-manager.io")
+	require.NoError(t, err)
+	assert.Equal(t, "Certificate", crd.Spec.Names.Kind)
+
+	crd, err = svc.GetCRD(ctx, "certificate")
+	require.NoError(t, err, "kind should match case-insensitively")
+	assert.Equal(t, "certificates.cert-manager.io", crd.Name)
+
+	_, err = svc.GetCRD(ctx, "issuers")
+	assert.ErrorContains(t, err, "ambiguous")
+
+	_, err = svc.GetCRD(ctx, "gadgets")
+	assert.Error(t, err)
+
+	_, err = svc.GetCRDInfo(ctx, "certificates")
+	assert.ErrorContains(t, err, "no served version")
+}
@@ -5,11 +5,17 @@ import (
 	"fmt"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
 
+	"github.com/pteich/crdlens/internal/config"
 	"github.com/pteich/crdlens/internal/types"
 )
 
@@ -20,7 +26,9 @@ const (
 
 // DynamicService handles CR instance operations
 type DynamicService struct {
-	client dynamic.Interface
+	client       dynamic.Interface
+	health       *HealthRules
+	statusEngine string
 }
 
 // NewDynamicService creates a new DynamicService
@@ -30,18 +38,50 @@ func NewDynamicService(client dynamic.Interface) *DynamicService {
 	}
 }
 
-// ListResourcesOptions configures pagination for listing resources
+// ListResourcesOptions configures pagination and server-side filtering for listing resources
 type ListResourcesOptions struct {
-	Limit    int64  // Number of resources per page (0 = use default)
-	Continue string // Continuation token for pagination
+	Limit         int64  // Number of resources per page (0 = use default)
+	Continue      string // Continuation token for pagination
+	LabelSelector string // Only return resources matching the label selector, e.g. "app=web"
+	FieldSelector string // Only return resources matching the field selector, e.g. "metadata.name=foo"
 }
 
 // ListResourcesResult contains the result of a paginated list operation
 type ListResourcesResult struct {
-	Resources      []types.Resource
-	ContinueToken  string // Token for next page (empty if no more pages)
-	RemainingCount *int64 // Approximate remaining items (may be nil)
-	TotalCount     int    // Total fetched so far (including this page)
+	Resources       []types.Resource
+	ContinueToken   string // Token for next page (empty if no more pages)
+	RemainingCount  *int64 // Approximate remaining items (may be nil)
+	TotalCount      int    // Total fetched so far (including this page)
+	ResourceVersion string // Resource version of the list snapshot, used to start a watch
+}
+
+// ResourceEventType describes the kind of change reported by a watch
+type ResourceEventType string
+
+const (
+	ResourceAdded    ResourceEventType = "Added"
+	ResourceModified ResourceEventType = "Modified"
+	ResourceDeleted  ResourceEventType = "Deleted"
+	// ResourceExpired is sent when the watch can no longer be resumed
+	// (resourceVersion too old) and the caller has to list again
+	ResourceExpired ResourceEventType = "Expired"
+	// ResourceWatchFailed is sent when the watch stopped for another reason,
+	// e.g. missing watch permission. Listing again would fail the same way.
+	ResourceWatchFailed ResourceEventType = "Failed"
+)
+
+// ResourceEvent is a single change streamed from a watch
+type ResourceEvent struct {
+	Type     ResourceEventType
+	Resource types.Resource
+	Err      error // Set for ResourceExpired and other terminal errors
+}
+
+// WatchResourcesOptions configures a watch on resources
+type WatchResourcesOptions struct {
+	ResourceVersion string // Resource version to start from (usually from the preceding list)
+	LabelSelector   string // Should match the selectors of the preceding list
+	FieldSelector   string
 }
 
 // ListResources lists instances of a CRD with optional pagination
@@ -61,8 +101,10 @@ func (s *DynamicService) ListResourcesPaginated(ctx context.Context, gvr schema.
 	}
 
 	listOpts := metav1.ListOptions{
-		Limit:    limit,
-		Continue: opts.Continue,
+		Limit:         limit,
+		Continue:      opts.Continue,
+		LabelSelector: opts.LabelSelector,
+		FieldSelector: opts.FieldSelector,
 	}
 
 	res, err := s.client.Resource(gvr).Namespace(namespace).List(ctx, listOpts)
@@ -76,28 +118,104 @@ func (s *DynamicService) ListResourcesPaginated(ctx context.Context, gvr schema.
 	}
 
 	return &ListResourcesResult{
-		Resources:      resources,
-		ContinueToken:  res.GetContinue(),
-		RemainingCount: res.GetRemainingItemCount(),
-		TotalCount:     len(resources),
+		Resources:       resources,
+		ContinueToken:   res.GetContinue(),
+		RemainingCount:  res.GetRemainingItemCount(),
+		TotalCount:      len(resources),
+		ResourceVersion: res.GetResourceVersion(),
 	}, nil
 }
 
-// ListAllResources fetches all resources across all pages
+// WatchResources streams add/update/delete events for instances of a CRD.
+// When the server closes the watch it is transparently resumed from the last
+// seen resourceVersion. If that version has expired a ResourceExpired event is
+// sent and the channel is closed, other errors end the watch with ResourceWatchFailed.
+// Cancel ctx to stop watching.
+func (s *DynamicService) WatchResources(ctx context.Context, gvr schema.GroupVersionResource, namespace string, opts WatchResourcesOptions) (<-chan ResourceEvent, error) {
+	ri := s.client.Resource(gvr).Namespace(namespace)
+	lw := &cache.ListWatch{
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = opts.LabelSelector
+			options.FieldSelector = opts.FieldSelector
+			return ri.Watch(ctx, options)
+		},
+	}
+
+	rw, err := watchtools.NewRetryWatcherWithContext(ctx, opts.ResourceVersion, lw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch %s: %w", gvr.Resource, err)
+	}
+
+	events := make(chan ResourceEvent)
+	go func() {
+		defer close(events)
+		defer rw.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-rw.ResultChan():
+				if !ok {
+					return
+				}
+
+				out, done := s.convertWatchEvent(ev, gvr)
+				select {
+				case events <- out:
+				case <-ctx.Done():
+					return
+				}
+				if done {
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// convertWatchEvent maps a raw watch event to a ResourceEvent.
+// The second return value reports whether the watch is finished.
+func (s *DynamicService) convertWatchEvent(ev watch.Event, gvr schema.GroupVersionResource) (ResourceEvent, bool) {
+	if ev.Type == watch.Error {
+		err := apierrors.FromObject(ev.Object)
+		if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+			return ResourceEvent{Type: ResourceExpired, Err: err}, true
+		}
+		return ResourceEvent{Type: ResourceWatchFailed, Err: fmt.Errorf("watch %s failed: %w", gvr.Resource, err)}, true
+	}
+
+	item, ok := ev.Object.(*unstructured.Unstructured)
+	if !ok {
+		return ResourceEvent{Type: ResourceWatchFailed, Err: fmt.Errorf("unexpected watch object %T", ev.Object)}, true
+	}
+
+	evType := ResourceModified
+	switch ev.Type {
+	case watch.Added:
+		evType = ResourceAdded
+	case watch.Deleted:
+		evType = ResourceDeleted
+	}
+
+	return ResourceEvent{Type: evType, Resource: s.itemToResource(*item, gvr)}, false
+}
+
+// ListAllResources fetches all resources across all pages. opts.Limit is used as page
+// size, the selectors of opts are applied to every page.
 // Use with caution for large result sets
-func (s *DynamicService) ListAllResources(ctx context.Context, gvr schema.GroupVersionResource, namespace string, pageSize int64) ([]types.Resource, error) {
-	if pageSize == 0 {
-		pageSize = DefaultPageSize
+func (s *DynamicService) ListAllResources(ctx context.Context, gvr schema.GroupVersionResource, namespace string, opts ListResourcesOptions) ([]types.Resource, error) {
+	if opts.Limit == 0 {
+		opts.Limit = DefaultPageSize
 	}
 
 	var allResources []types.Resource
-	continueToken := ""
+	opts.Continue = ""
 
 	for {
-		result, err := s.ListResourcesPaginated(ctx, gvr, namespace, ListResourcesOptions{
-			Limit:    pageSize,
-			Continue: continueToken,
-		})
+		result, err := s.ListResourcesPaginated(ctx, gvr, namespace, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -107,7 +225,7 @@ func (s *DynamicService) ListAllResources(ctx context.Context, gvr schema.GroupV
 		if result.ContinueToken == "" {
 			break
 		}
-		continueToken = result.ContinueToken
+		opts.Continue = result.ContinueToken
 	}
 
 	return allResources, nil
@@ -124,6 +242,55 @@ func (s *DynamicService) GetResource(ctx context.Context, gvr schema.GroupVersio
 	return &resource, nil
 }
 
+// Update replaces a CR instance with the given object.
+// The object's resourceVersion is sent along, so concurrent modifications are reported as conflicts.
+func (s *DynamicService) Update(ctx context.Context, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) (*types.Resource, error) {
+	item, err := s.client.Resource(gvr).Namespace(obj.GetNamespace()).Update(ctx, obj, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	resource := s.itemToResource(*item, gvr)
+	return &resource, nil
+}
+
+// DeleteOptions configures how a CR instance is deleted
+type DeleteOptions struct {
+	PropagationPolicy metav1.DeletionPropagation // Background, Foreground or Orphan (empty = server default)
+}
+
+// Delete deletes a CR instance. Resources with finalizers stay in Terminating
+// until their controller removes the finalizers.
+func (s *DynamicService) Delete(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string, opts DeleteOptions) error {
+	deleteOpts := metav1.DeleteOptions{}
+	if opts.PropagationPolicy != "" {
+		policy := opts.PropagationPolicy
+		deleteOpts.PropagationPolicy = &policy
+	}
+
+	if err := s.client.Resource(gvr).Namespace(namespace).Delete(ctx, name, deleteOpts); err != nil {
+		return fmt.Errorf("failed to delete %s/%s: %w", gvr.Resource, name, err)
+	}
+	return nil
+}
+
+// Patch applies a patch of the given type to a CR instance
+func (s *DynamicService) Patch(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string, pt k8stypes.PatchType, data []byte) (*types.Resource, error) {
+	item, err := s.client.Resource(gvr).Namespace(namespace).Patch(ctx, name, pt, data, metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to patch %s/%s: %w", gvr.Resource, name, err)
+	}
+
+	resource := s.itemToResource(*item, gvr)
+	return &resource, nil
+}
+
+// RemoveFinalizers clears metadata.finalizers so a resource stuck in Terminating
+// (e.g. because its controller is gone) can be removed by the API server
+func (s *DynamicService) RemoveFinalizers(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*types.Resource, error) {
+	return s.Patch(ctx, gvr, namespace, name, k8stypes.MergePatchType, []byte(`{"metadata":{"finalizers":null}}`))
+}
+
 // CountResources counts the number of CR instances for a given GVR
 func (s *DynamicService) CountResources(ctx context.Context, gvr schema.GroupVersionResource, namespace string) (int, error) {
 	// Use limit=1 to minimize data transfer, just get the count
@@ -159,6 +326,22 @@ func (s *DynamicService) itemToResource(item unstructured.Unstructured, gvr sche
 	conditions := ExtractConditions(&item)
 	controllerManager, lastStatusWrite, lastSpecWrite := ExtractControllerInfo(item.GetManagedFields())
 
+	// Health rules win, the status engine applies to all other resources. The kstatus of
+	// single resources is computed by WithKStatus when it is shown.
+	health := s.health.Status(&item, conditions)
+	var kstatus, kstatusMessage string
+	if s.statusEngine == config.StatusEngineKStatus {
+		kstatus, kstatusMessage = ComputeKStatus(&item)
+		if health == "" {
+			health = KStatusReadyStatus(kstatus)
+		}
+	}
+
+	var deletionTimestamp time.Time
+	if ts := item.GetDeletionTimestamp(); ts != nil {
+		deletionTimestamp = ts.Time
+	}
+
 	return types.Resource{
 		// Basic metadata
 		Name:      item.GetName(),
@@ -170,6 +353,14 @@ func (s *DynamicService) itemToResource(item unstructured.Unstructured, gvr sche
 		CreatedAt: creationTimestamp.Time,
 		Raw:       &item,
 
+		// Deletion state
+		DeletionTimestamp: deletionTimestamp,
+		Finalizers:        item.GetFinalizers(),
+		Suspended:         IsSuspended(&item),
+		Health:            health,
+		KStatus:           kstatus,
+		KStatusMessage:    kstatusMessage,
+
 		// Controller-Aware Fields
 		Generation:         item.GetGeneration(),
 		ObservedGeneration: observedGen,
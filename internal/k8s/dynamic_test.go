@@ -0,0 +1,176 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+var testGVR = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+
+func newTestWidget(name, resourceVersion string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("example.com/v1")
+	u.SetKind("Widget")
+	u.SetNamespace("default")
+	u.SetName(name)
+	u.SetResourceVersion(resourceVersion)
+	return u
+}
+
+func newTestDynamicClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{testGVR: "WidgetList"}, objects...)
+}
+
+func nextResourceEvent(t *testing.T, events <-chan ResourceEvent) ResourceEvent {
+	t.Helper()
+	select {
+	case ev, ok := <-events:
+		require.True(t, ok, "watch channel closed unexpectedly")
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+		return ResourceEvent{}
+	}
+}
+
+func TestDynamicService_ListResourcesPaginated_Selectors(t *testing.T) {
+	web := newTestWidget("web", "1")
+	web.SetLabels(map[string]string{"app": "web"})
+	db := newTestWidget("db", "1")
+	db.SetLabels(map[string]string{"app": "db"})
+	client := newTestDynamicClient(web, db)
+	svc := NewDynamicService(client)
+
+	result, err := svc.ListResourcesPaginated(context.Background(), testGVR, "default", ListResourcesOptions{
+		LabelSelector: "app=web",
+		FieldSelector: "metadata.name=web",
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Resources, 1)
+	assert.Equal(t, "web", result.Resources[0].Name)
+
+	// The selectors are sent to the server
+	list := client.Actions()[0].(clienttesting.ListAction)
+	assert.Equal(t, "app=web", list.GetListRestrictions().Labels.String())
+	assert.Equal(t, "metadata.name=web", list.GetListRestrictions().Fields.String())
+}
+
+func TestDynamicService_WatchResources(t *testing.T) {
+	client := newTestDynamicClient()
+	svc := NewDynamicService(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := svc.WatchResources(ctx, testGVR, "default", WatchResourcesOptions{ResourceVersion: "1"})
+	require.NoError(t, err)
+
+	// The fake client only delivers events to watches that are already established
+	require.Eventually(t, func() bool {
+		return len(client.Actions()) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	ri := client.Resource(testGVR).Namespace("default")
+	_, err = ri.Create(ctx, newTestWidget("foo", "2"), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	ev := nextResourceEvent(t, events)
+	assert.Equal(t, ResourceAdded, ev.Type)
+	assert.Equal(t, "foo", ev.Resource.Name)
+	assert.Equal(t, testGVR, ev.Resource.GVR)
+
+	err = ri.Delete(ctx, "foo", metav1.DeleteOptions{})
+	require.NoError(t, err)
+
+	ev = nextResourceEvent(t, events)
+	assert.Equal(t, ResourceDeleted, ev.Type)
+	assert.Equal(t, "foo", ev.Resource.Name)
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		_, ok := <-events
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond, "channel should be closed after cancel")
+}
+
+func TestDynamicService_WatchResources_RequiresResourceVersion(t *testing.T) {
+	svc := NewDynamicService(newTestDynamicClient())
+
+	_, err := svc.WatchResources(context.Background(), testGVR, "default", WatchResourcesOptions{})
+	assert.Error(t, err)
+}
+
+func TestDynamicService_Update(t *testing.T) {
+	client := newTestDynamicClient(newTestWidget("foo", "1"))
+	svc := NewDynamicService(client)
+
+	obj := newTestWidget("foo", "1")
+	obj.SetLabels(map[string]string{"app": "demo"})
+
+	res, err := svc.Update(context.Background(), testGVR, obj)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", res.Name)
+	assert.Equal(t, "demo", res.Raw.GetLabels()["app"])
+
+	_, err = svc.Update(context.Background(), testGVR, newTestWidget("missing", "1"))
+	assert.Error(t, err)
+}
+
+func TestDynamicService_Delete(t *testing.T) {
+	client := newTestDynamicClient(newTestWidget("foo", "1"))
+	svc := NewDynamicService(client)
+
+	err := svc.Delete(context.Background(), testGVR, "default", "foo", DeleteOptions{
+		PropagationPolicy: metav1.DeletePropagationForeground,
+	})
+	require.NoError(t, err)
+
+	_, err = svc.GetResource(context.Background(), testGVR, "default", "foo")
+	assert.Error(t, err, "resource should be gone")
+
+	err = svc.Delete(context.Background(), testGVR, "default", "foo", DeleteOptions{})
+	assert.Error(t, err)
+}
+
+func TestDynamicService_RemoveFinalizers(t *testing.T) {
+	widget := newTestWidget("foo", "1")
+	widget.SetFinalizers([]string{"example.com/cleanup"})
+	svc := NewDynamicService(newTestDynamicClient(widget))
+
+	res, err := svc.GetResource(context.Background(), testGVR, "default", "foo")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com/cleanup"}, res.Finalizers)
+
+	res, err = svc.RemoveFinalizers(context.Background(), testGVR, "default", "foo")
+	require.NoError(t, err)
+	assert.Empty(t, res.Finalizers)
+}
+
+func TestDynamicService_ConvertWatchEvent_Errors(t *testing.T) {
+	svc := NewDynamicService(newTestDynamicClient())
+
+	expired := apierrors.NewResourceExpired("too old resource version")
+	ev, done := svc.convertWatchEvent(watch.Event{Type: watch.Error, Object: &expired.ErrStatus}, testGVR)
+	assert.True(t, done)
+	assert.Equal(t, ResourceExpired, ev.Type)
+
+	forbidden := apierrors.NewForbidden(testGVR.GroupResource(), "", errors.New("no watch permission"))
+	ev, done = svc.convertWatchEvent(watch.Event{Type: watch.Error, Object: &forbidden.ErrStatus}, testGVR)
+	assert.True(t, done)
+	assert.Equal(t, ResourceWatchFailed, ev.Type)
+	assert.ErrorContains(t, ev.Err, "no watch permission")
+}
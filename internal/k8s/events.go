@@ -3,8 +3,10 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pteich/crdlens/internal/types"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
@@ -33,6 +35,10 @@ func (s *EventService) GetEventsForResource(ctx context.Context, namespace, uid
 
 	var events []types.Event
 	for _, item := range list.Items {
+		// Fake clients of the offline mode ignore field selectors
+		if string(item.InvolvedObject.UID) != uid {
+			continue
+		}
 		events = append(events, types.Event{
 			Type:          item.Type,
 			Reason:        item.Reason,
@@ -44,3 +50,43 @@ func (s *EventService) GetEventsForResource(ctx context.Context, namespace, uid
 
 	return events, nil
 }
+
+// RecentWarningWindow is how far back Warning events count for the reconcile state
+const RecentWarningWindow = 15 * time.Minute
+
+// CountRecentWarnings counts the Warning events of all objects in the namespace
+// seen after since, indexed by the UID of the involved object. Repeated events
+// count with their number of occurrences.
+func (s *EventService) CountRecentWarnings(ctx context.Context, since time.Time) (map[string]int, error) {
+	list, err := s.client.List(ctx, metav1.ListOptions{
+		FieldSelector: "type=" + corev1.EventTypeWarning,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	counts := make(map[string]int)
+	for _, item := range list.Items {
+		lastSeen := item.LastTimestamp.Time
+		if lastSeen.IsZero() {
+			lastSeen = item.EventTime.Time
+		}
+		event := types.Event{Type: item.Type, LastTimestamp: lastSeen, Count: item.Count}
+		if n := CountRecentWarnings([]types.Event{event}, since); n > 0 {
+			counts[string(item.InvolvedObject.UID)] += n
+		}
+	}
+	return counts, nil
+}
+
+// CountRecentWarnings counts the occurrences of Warning events seen after since
+func CountRecentWarnings(events []types.Event, since time.Time) int {
+	count := 0
+	for _, e := range events {
+		if e.Type != corev1.EventTypeWarning || !e.LastTimestamp.After(since) {
+			continue
+		}
+		count += max(int(e.Count), 1)
+	}
+	return count
+}
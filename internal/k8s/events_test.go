@@ -45,3 +45,29 @@ func TestEventService_GetEventsForResource(t *testing.T) {
 	assert.Equal(t, "Created", events[0].Reason)
 	assert.Equal(t, "Created resource", events[0].Message)
 }
+
+func TestEventService_CountRecentWarnings(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	eventsClient := clientset.CoreV1().Events("default")
+
+	now := time.Now()
+	events := []*corev1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "warning"}, InvolvedObject: corev1.ObjectReference{UID: "a"},
+			Type: corev1.EventTypeWarning, LastTimestamp: metav1.Time{Time: now.Add(-time.Minute)}, Count: 4},
+		{ObjectMeta: metav1.ObjectMeta{Name: "new-style"}, InvolvedObject: corev1.ObjectReference{UID: "a"},
+			Type: corev1.EventTypeWarning, EventTime: metav1.MicroTime{Time: now.Add(-time.Minute)}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "old"}, InvolvedObject: corev1.ObjectReference{UID: "b"},
+			Type: corev1.EventTypeWarning, LastTimestamp: metav1.Time{Time: now.Add(-time.Hour)}, Count: 1},
+		// Fake clients ignore field selectors, normal events must be skipped anyway
+		{ObjectMeta: metav1.ObjectMeta{Name: "normal"}, InvolvedObject: corev1.ObjectReference{UID: "b"},
+			Type: corev1.EventTypeNormal, LastTimestamp: metav1.Time{Time: now}, Count: 1},
+	}
+	for _, e := range events {
+		_, err := eventsClient.Create(context.Background(), e, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	counts, err := NewEventService(eventsClient).CountRecentWarnings(context.Background(), now.Add(-RecentWarningWindow))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 5}, counts)
+}
@@ -0,0 +1,164 @@
+package k8s
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/pteich/crdlens/internal/types"
+)
+
+// healthCostLimit bounds the evaluation cost of a single health expression
+const healthCostLimit = 100000
+
+// HealthRules evaluates the health rules of the config against resources
+type HealthRules struct {
+	rules map[string]*healthRule // By GroupKind key, Kind.group or *.group
+}
+
+// healthRule is a HealthRule with parsed JSONPath and compiled expressions
+type healthRule struct {
+	types.HealthRule
+	field       *jsonpath.JSONPath
+	ready       cel.Program
+	notReady    cel.Program
+	progressing cel.Program
+}
+
+// NewHealthRules parses the JSONPaths and compiles the CEL expressions of the rules
+func NewHealthRules(rules map[string]types.HealthRule) (*HealthRules, error) {
+	env, err := cel.NewEnv(cel.Variable("self", cel.DynType))
+	if err != nil {
+		return nil, err
+	}
+
+	h := &HealthRules{rules: make(map[string]*healthRule, len(rules))}
+	for key, rule := range rules {
+		compiled := &healthRule{HealthRule: rule}
+		if rule.Field != "" {
+			compiled.field = jsonpath.New(key).AllowMissingKeys(true)
+			if err := compiled.field.Parse(fmt.Sprintf("{%s}", rule.Field)); err != nil {
+				return nil, fmt.Errorf("invalid health rule %s: field: %w", key, err)
+			}
+		}
+		for _, expr := range []struct {
+			name string
+			src  string
+			prg  *cel.Program
+		}{
+			{"ready", rule.Expressions.Ready, &compiled.ready},
+			{"notReady", rule.Expressions.NotReady, &compiled.notReady},
+			{"progressing", rule.Expressions.Progressing, &compiled.progressing},
+		} {
+			if expr.src == "" {
+				continue
+			}
+			prg, err := compileHealthExpression(env, expr.src)
+			if err != nil {
+				return nil, fmt.Errorf("invalid health rule %s: expressions.%s: %w", key, expr.name, err)
+			}
+			*expr.prg = prg
+		}
+		h.rules[key] = compiled
+	}
+	return h, nil
+}
+
+// compileHealthExpression compiles a CEL expression that has to evaluate to a bool
+func compileHealthExpression(env *cel.Env, src string) (cel.Program, error) {
+	ast, issues := env.Compile(src)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("expression must evaluate to bool, not %s", ast.OutputType())
+	}
+	return env.Program(ast, cel.CostLimit(healthCostLimit))
+}
+
+// rule returns the rule for the GroupKind of obj, rules of the kind win over rules of the group
+func (h *HealthRules) rule(obj *unstructured.Unstructured) *healthRule {
+	if h == nil || obj == nil {
+		return nil
+	}
+	gk := obj.GroupVersionKind().GroupKind()
+	if rule, ok := h.rules[gk.String()]; ok {
+		return rule
+	}
+	return h.rules["*."+gk.Group]
+}
+
+// Status returns the ready status of obj by its rule, or "" if no rule applies
+func (h *HealthRules) Status(obj *unstructured.Unstructured, conditions []types.Condition) string {
+	rule := h.rule(obj)
+	if rule == nil {
+		return ""
+	}
+
+	var ready, notReady, progressing bool
+	for _, c := range conditions {
+		if slices.Contains(rule.ReadyConditions, c.Type) {
+			switch c.Status {
+			case "True":
+				ready = true
+			case "False":
+				notReady = true
+			}
+		}
+		if c.Status == "True" && slices.Contains(rule.NotReadyConditions, c.Type) {
+			notReady = true
+		}
+		if c.Status == "True" && slices.Contains(rule.ProgressingConditions, c.Type) {
+			progressing = true
+		}
+	}
+
+	if value, found := fieldValue(rule.field, obj); found {
+		ready = ready || slices.Contains(rule.Ready, value)
+		notReady = notReady || slices.Contains(rule.NotReady, value)
+		progressing = progressing || slices.Contains(rule.Progressing, value)
+	}
+
+	ready = ready || evalHealthExpression(rule.ready, obj)
+	notReady = notReady || evalHealthExpression(rule.notReady, obj)
+	progressing = progressing || evalHealthExpression(rule.progressing, obj)
+
+	return types.ResolveReadyStatus(ready, notReady, progressing)
+}
+
+// fieldValue returns the first value at the JSONPath of a rule as string
+func fieldValue(field *jsonpath.JSONPath, obj *unstructured.Unstructured) (string, bool) {
+	if field == nil {
+		return "", false
+	}
+	results, err := field.FindResults(obj.Object)
+	if err != nil {
+		return "", false
+	}
+	for _, result := range results {
+		for _, v := range result {
+			if v.IsValid() && v.CanInterface() && v.Interface() != nil {
+				return strings.TrimSpace(fmt.Sprint(v.Interface())), true
+			}
+		}
+	}
+	return "", false
+}
+
+// evalHealthExpression returns true if the expression evaluates to true. Errors, e.g.
+// because a field is missing, count as false.
+func evalHealthExpression(prg cel.Program, obj *unstructured.Unstructured) bool {
+	if prg == nil {
+		return false
+	}
+	out, _, err := prg.Eval(map[string]any{"self": obj.Object})
+	if err != nil {
+		return false
+	}
+	result, ok := out.Value().(bool)
+	return ok && result
+}
@@ -0,0 +1,156 @@
+package k8s
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/pteich/crdlens/internal/config"
+	"github.com/pteich/crdlens/internal/types"
+)
+
+func TestHealthRules_Status(t *testing.T) {
+	rules, err := NewHealthRules(config.DefaultHealthRules())
+	require.NoError(t, err)
+
+	application := newTestObject("argoproj.io/v1alpha1", "Application", "app")
+	require.NoError(t, unstructured.SetNestedField(application.Object, "Healthy", "status", "health", "status"))
+	rollout := newTestObject("argoproj.io/v1alpha1", "Rollout", "rollout")
+	require.NoError(t, unstructured.SetNestedField(rollout.Object, "Paused", "status", "phase"))
+	running := newTestObject("tekton.dev/v1", "PipelineRun", "run")
+	require.NoError(t, unstructured.SetNestedSlice(running.Object, []interface{}{
+		map[string]interface{}{"type": "Succeeded", "status": "Unknown"},
+	}, "status", "conditions"))
+
+	tests := []struct {
+		name       string
+		obj        *unstructured.Unstructured
+		conditions []types.Condition
+		want       string
+	}{
+		{
+			name: "no rule",
+			obj:  newTestWidget("test", "1"),
+			want: "",
+		},
+		{
+			name:       "flux stalled",
+			obj:        newTestObject("kustomize.toolkit.fluxcd.io/v1", "Kustomization", "apps"),
+			conditions: []types.Condition{{Type: "Ready", Status: "True"}, {Type: "Stalled", Status: "True"}},
+			want:       "NotReady",
+		},
+		{
+			name:       "flux reconciling",
+			obj:        newTestObject("helm.toolkit.fluxcd.io/v2", "HelmRelease", "release"),
+			conditions: []types.Condition{{Type: "Ready", Status: "Unknown"}, {Type: "Reconciling", Status: "True"}},
+			want:       "Progressing",
+		},
+		{
+			name:       "argo health wins over error conditions",
+			obj:        application,
+			conditions: []types.Condition{{Type: "ComparisonError", Status: "True"}},
+			want:       "Ready",
+		},
+		{
+			name: "rollout paused",
+			obj:  rollout,
+			want: "Progressing",
+		},
+		{
+			name:       "tekton running",
+			obj:        running,
+			conditions: []types.Condition{{Type: "Succeeded", Status: "Unknown"}},
+			want:       "Progressing",
+		},
+		{
+			name:       "tekton failed",
+			obj:        newTestObject("tekton.dev/v1", "PipelineRun", "run"),
+			conditions: []types.Condition{{Type: "Succeeded", Status: "False"}},
+			want:       "NotReady",
+		},
+		{
+			name: "rule without matching status",
+			obj:  newTestObject("cert-manager.io/v1", "Certificate", "cert"),
+			want: "Unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rules.Status(tt.obj, tt.conditions))
+		})
+	}
+}
+
+func TestHealthRules_KindOverridesGroup(t *testing.T) {
+	rules, err := NewHealthRules(map[string]types.HealthRule{
+		"*.example.com":      {Field: ".status.phase", Ready: []string{"Up"}},
+		"Gadget.example.com": {Expressions: types.HealthExpressions{NotReady: "self.status.available < self.spec.replicas"}},
+	})
+	require.NoError(t, err)
+
+	widget := newTestWidget("widget", "1")
+	require.NoError(t, unstructured.SetNestedField(widget.Object, "Up", "status", "phase"))
+	assert.Equal(t, "Ready", rules.Status(widget, nil))
+
+	gadget := newTestObject("example.com/v1", "Gadget", "gadget")
+	require.NoError(t, unstructured.SetNestedField(gadget.Object, int64(3), "spec", "replicas"))
+	// Missing fields make expressions false
+	assert.Equal(t, "Unknown", rules.Status(gadget, nil))
+
+	require.NoError(t, unstructured.SetNestedField(gadget.Object, "Up", "status", "phase"))
+	require.NoError(t, unstructured.SetNestedField(gadget.Object, int64(1), "status", "available"))
+	assert.Equal(t, "NotReady", rules.Status(gadget, nil))
+
+	var none *HealthRules
+	assert.Empty(t, none.Status(widget, nil))
+}
+
+func TestNewHealthRules_Invalid(t *testing.T) {
+	_, err := NewHealthRules(map[string]types.HealthRule{
+		"Widget.example.com": {Expressions: types.HealthExpressions{Ready: "self.status.phase =="}},
+	})
+	assert.ErrorContains(t, err, "Widget.example.com: expressions.ready")
+
+	_, err = NewHealthRules(map[string]types.HealthRule{
+		"Widget.example.com": {Expressions: types.HealthExpressions{Ready: "'not a bool'"}},
+	})
+	assert.ErrorContains(t, err, "must evaluate to bool")
+
+	_, err = NewHealthRules(map[string]types.HealthRule{
+		"Widget.example.com": {Field: ".status[phase"},
+	})
+	assert.ErrorContains(t, err, "Widget.example.com: field")
+}
+
+func TestNewOfflineClient_HealthRules(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifests.yaml"), []byte(offlineTestManifests), 0o644))
+
+	cfg := config.DefaultConfig()
+	cfg.FromDir = dir
+	cfg.Health = map[string]types.HealthRule{"Widget.example.com": {
+		Expressions: types.HealthExpressions{Ready: "self.spec.size > 1"},
+	}}
+	client, err := NewClient(cfg)
+	require.NoError(t, err)
+
+	gvr := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	resources, err := client.Dynamic().ListAllResources(context.Background(), gvr, "", ListResourcesOptions{})
+	require.NoError(t, err)
+	status := make(map[string]string)
+	for _, res := range resources {
+		status[res.Name] = res.ReadyStatus()
+	}
+	assert.Equal(t, map[string]string{"first": "Unknown", "second": "Ready"}, status)
+
+	cfg.Health["Widget.example.com"] = types.HealthRule{Expressions: types.HealthExpressions{Ready: "self.spec.size +"}}
+	_, err = NewClient(cfg)
+	assert.ErrorContains(t, err, "invalid health rule Widget.example.com")
+}
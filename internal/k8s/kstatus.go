@@ -0,0 +1,58 @@
+package k8s
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+
+	"github.com/pteich/crdlens/internal/config"
+	"github.com/pteich/crdlens/internal/types"
+)
+
+// ComputeKStatus returns the kstatus of obj (InProgress, Current, Failed, Terminating or
+// Unknown) with its message, the same status kubectl and kpt wait for
+func ComputeKStatus(obj *unstructured.Unstructured) (string, string) {
+	if obj == nil {
+		return status.UnknownStatus.String(), ""
+	}
+	result, err := status.Compute(obj)
+	if err != nil {
+		return status.UnknownStatus.String(), err.Error()
+	}
+	return result.Status.String(), result.Message
+}
+
+// WithKStatus returns res with its kstatus, computed from the raw object if it isn't set.
+// Resources are only listed with their kstatus if it is the status engine.
+func WithKStatus(res types.Resource) types.Resource {
+	if res.KStatus == "" && res.Raw != nil {
+		res.KStatus, res.KStatusMessage = ComputeKStatus(res.Raw)
+	}
+	return res
+}
+
+// KStatusReadyStatus maps a kstatus to the ready status shown by crdlens
+func KStatusReadyStatus(kstatus string) string {
+	switch status.Status(kstatus) {
+	case status.CurrentStatus:
+		return "Ready"
+	case status.InProgressStatus:
+		return "Progressing"
+	case status.FailedStatus:
+		return "NotReady"
+	case status.TerminatingStatus:
+		return "Terminating"
+	default:
+		return "Unknown"
+	}
+}
+
+// checkStatusEngine returns an error for status engines other than builtin and kstatus
+func checkStatusEngine(engine string) error {
+	switch engine {
+	case "", config.StatusEngineBuiltin, config.StatusEngineKStatus:
+		return nil
+	}
+	return fmt.Errorf("invalid statusEngine %q, use %s or %s", engine, config.StatusEngineBuiltin, config.StatusEngineKStatus)
+}
@@ -0,0 +1,109 @@
+package k8s
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/pteich/crdlens/internal/config"
+	"github.com/pteich/crdlens/internal/types"
+)
+
+func TestComputeKStatus(t *testing.T) {
+	ready := map[string]interface{}{"type": "Ready", "status": "True"}
+	stalled := map[string]interface{}{"type": "Stalled", "status": "True", "message": "bad spec"}
+
+	tests := []struct {
+		name       string
+		generation int64
+		observed   int64
+		conditions []interface{}
+		want       string
+		message    string
+	}{
+		{"ready", 2, 2, []interface{}{ready}, "Current", ""},
+		{"not ready", 2, 2, []interface{}{map[string]interface{}{"type": "Ready", "status": "False"}}, "InProgress", ""},
+		{"stalled", 2, 2, []interface{}{stalled}, "Failed", "bad spec"},
+		{"drift", 3, 2, []interface{}{ready}, "InProgress", ""},
+		{"no conditions", 1, 1, nil, "Current", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := newTestObject("example.com/v1", "Widget", "test")
+			obj.SetGeneration(tt.generation)
+			require.NoError(t, unstructured.SetNestedField(obj.Object, tt.observed, "status", "observedGeneration"))
+			require.NoError(t, unstructured.SetNestedSlice(obj.Object, tt.conditions, "status", "conditions"))
+
+			got, message := ComputeKStatus(obj)
+			assert.Equal(t, tt.want, got)
+			if tt.message != "" {
+				assert.Equal(t, tt.message, message)
+			}
+		})
+	}
+
+	got, _ := ComputeKStatus(nil)
+	assert.Equal(t, "Unknown", got)
+}
+
+func TestKStatusReadyStatus(t *testing.T) {
+	assert.Equal(t, "Ready", KStatusReadyStatus("Current"))
+	assert.Equal(t, "Progressing", KStatusReadyStatus("InProgress"))
+	assert.Equal(t, "NotReady", KStatusReadyStatus("Failed"))
+	assert.Equal(t, "Terminating", KStatusReadyStatus("Terminating"))
+	assert.Equal(t, "Unknown", KStatusReadyStatus("NotFound"))
+}
+
+func TestNewOfflineClient_StatusEngine(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifests.yaml"), []byte(offlineTestManifests), 0o644))
+	gvr := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+
+	statuses := func(cfg *config.Config) map[string]string {
+		client, err := NewClient(cfg)
+		require.NoError(t, err)
+		resources, err := client.Dynamic().ListAllResources(context.Background(), gvr, "", ListResourcesOptions{})
+		require.NoError(t, err)
+		status := make(map[string]string)
+		for _, res := range resources {
+			if cfg.StatusEngine == config.StatusEngineKStatus {
+				assert.Equal(t, "Current", res.KStatus)
+			} else {
+				assert.Empty(t, res.KStatus, "kstatus is only computed with the kstatus engine")
+				assert.Equal(t, "Current", WithKStatus(res).KStatus)
+			}
+			status[res.Name] = res.ReadyStatus()
+		}
+		return status
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.FromDir = dir
+	assert.Equal(t, map[string]string{"first": "Unknown", "second": "Unknown"}, statuses(cfg))
+
+	// Without conditions kstatus considers the widgets current
+	cfg.StatusEngine = config.StatusEngineKStatus
+	assert.Equal(t, map[string]string{"first": "Ready", "second": "Ready"}, statuses(cfg))
+
+	// Health rules win over the engine
+	cfg.Health = map[string]types.HealthRule{
+		"Widget.example.com": {Expressions: types.HealthExpressions{NotReady: "self.spec.size < 2"}},
+	}
+	assert.Equal(t, map[string]string{"first": "NotReady", "second": "Unknown"}, statuses(cfg))
+
+	// kstatus replaces the built-in rules
+	client, err := NewClient(cfg)
+	require.NoError(t, err)
+	assert.NotContains(t, client.Health.rules, "*.kustomize.toolkit.fluxcd.io")
+	assert.Contains(t, client.Health.rules, "Widget.example.com")
+
+	cfg.StatusEngine = "magic"
+	_, err = NewClient(cfg)
+	assert.ErrorContains(t, err, `invalid statusEngine "magic"`)
+}
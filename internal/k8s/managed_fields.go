@@ -0,0 +1,166 @@
+package k8s
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v6/value"
+)
+
+// FieldOwnership is a managedFields entry decoded into the field paths it owns
+type FieldOwnership struct {
+	Manager     string
+	Operation   string // Apply or Update
+	Subresource string // Empty for the main resource, e.g. status for the status subresource
+	APIVersion  string
+	Time        time.Time
+	// Paths in the notation of the Fields view, e.g. spec.replicas or status.conditions[0].status.
+	// List items that no longer exist in the object are shown by their key, e.g. [type=Ready].
+	Paths []string
+}
+
+// ExtractFieldOwnership decodes the FieldsV1 entries of all managedFields of obj.
+// Entries are sorted by time, the most recent write first.
+func ExtractFieldOwnership(obj *unstructured.Unstructured) ([]FieldOwnership, error) {
+	var owners []FieldOwnership
+	for _, mf := range obj.GetManagedFields() {
+		owner := FieldOwnership{
+			Manager:     mf.Manager,
+			Operation:   string(mf.Operation),
+			Subresource: mf.Subresource,
+			APIVersion:  mf.APIVersion,
+		}
+		if mf.Time != nil {
+			owner.Time = mf.Time.Time
+		}
+
+		if mf.FieldsV1 != nil {
+			set := &fieldpath.Set{}
+			if err := set.FromJSON(bytes.NewReader(mf.FieldsV1.Raw)); err != nil {
+				return nil, fmt.Errorf("invalid managed fields of %s: %w", mf.Manager, err)
+			}
+			set.Iterate(func(p fieldpath.Path) {
+				owner.Paths = append(owner.Paths, resolveFieldPath(obj.Object, p))
+			})
+			sort.Strings(owner.Paths)
+		}
+		owners = append(owners, owner)
+	}
+
+	sort.SliceStable(owners, func(i, j int) bool {
+		return owners[i].Time.After(owners[j].Time)
+	})
+	return owners, nil
+}
+
+// FieldOwners indexes the ownership by field path. Fields owned by more than one
+// manager are candidates for conflicts between field managers.
+func FieldOwners(owners []FieldOwnership) map[string][]string {
+	byPath := make(map[string][]string)
+	for _, o := range owners {
+		for _, p := range o.Paths {
+			byPath[p] = append(byPath[p], o.Manager)
+		}
+	}
+	return byPath
+}
+
+// resolveFieldPath converts a field path to the notation of the Fields view. Keys and
+// values of associative and set lists are resolved to the index of the item in obj.
+func resolveFieldPath(obj interface{}, p fieldpath.Path) string {
+	var sb strings.Builder
+	current := obj
+	for _, pe := range p {
+		switch {
+		case pe.FieldName != nil:
+			if sb.Len() > 0 {
+				sb.WriteString(".")
+			}
+			sb.WriteString(*pe.FieldName)
+			m, _ := current.(map[string]interface{})
+			current = m[*pe.FieldName]
+
+		case pe.Index != nil:
+			fmt.Fprintf(&sb, "[%d]", *pe.Index)
+			current = listItem(current, *pe.Index)
+
+		default:
+			idx := findListItem(current, pe)
+			if idx < 0 {
+				sb.WriteString(formatPathElement(pe))
+				current = nil
+				continue
+			}
+			fmt.Fprintf(&sb, "[%d]", idx)
+			current = listItem(current, idx)
+		}
+	}
+	return sb.String()
+}
+
+// findListItem returns the index of the list item identified by its key or value, or -1
+func findListItem(list interface{}, pe fieldpath.PathElement) int {
+	items, _ := list.([]interface{})
+	for i, item := range items {
+		switch {
+		case pe.Key != nil:
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			matches := true
+			for _, f := range *pe.Key {
+				v, found := m[f.Name]
+				if !found || !value.Equals(value.NewValueInterface(v), f.Value) {
+					matches = false
+					break
+				}
+			}
+			if matches {
+				return i
+			}
+		case pe.Value != nil:
+			if value.Equals(value.NewValueInterface(item), *pe.Value) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// formatPathElement formats keys and values of list items that are not part of the object
+func formatPathElement(pe fieldpath.PathElement) string {
+	switch {
+	case pe.Key != nil:
+		parts := make([]string, 0, len(*pe.Key))
+		for _, f := range *pe.Key {
+			parts = append(parts, f.Name+"="+formatPathValue(f.Value))
+		}
+		return "[" + strings.Join(parts, ",") + "]"
+	case pe.Value != nil:
+		return "[=" + formatPathValue(*pe.Value) + "]"
+	}
+	return pe.String()
+}
+
+// formatPathValue prints strings without quotes, other values as JSON
+func formatPathValue(v value.Value) string {
+	if v.IsString() {
+		return v.AsString()
+	}
+	return value.ToString(v)
+}
+
+// listItem returns the item at index i, or nil if list isn't a list or too short
+func listItem(list interface{}, i int) interface{} {
+	items, _ := list.([]interface{})
+	if i < 0 || i >= len(items) {
+		return nil
+	}
+	return items[i]
+}
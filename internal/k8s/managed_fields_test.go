@@ -0,0 +1,123 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const managedFieldsTestObject = `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: web
+  labels:
+    app: web
+  managedFields:
+    - manager: argocd-controller
+      operation: Apply
+      apiVersion: example.com/v1
+      time: "2024-01-01T10:00:00Z"
+      fieldsType: FieldsV1
+      fieldsV1:
+        f:metadata:
+          f:labels:
+            f:app: {}
+        f:spec:
+          f:replicas: {}
+          f:ports:
+            k:{"port":80,"protocol":"TCP"}:
+              .: {}
+              f:port: {}
+    - manager: widget-operator
+      operation: Update
+      subresource: status
+      apiVersion: example.com/v1
+      time: "2024-01-02T10:00:00Z"
+      fieldsType: FieldsV1
+      fieldsV1:
+        f:status:
+          f:conditions:
+            k:{"type":"Ready"}:
+              .: {}
+              f:status: {}
+            k:{"type":"Gone"}:
+              .: {}
+    - manager: kubectl-edit
+      operation: Update
+      apiVersion: example.com/v1
+      time: "2024-01-01T12:00:00Z"
+      fieldsType: FieldsV1
+      fieldsV1:
+        f:spec:
+          f:replicas: {}
+          f:tags:
+            v:"blue": {}
+spec:
+  replicas: 3
+  tags: ["green", "blue"]
+  ports:
+    - port: 443
+      protocol: TCP
+    - port: 80
+      protocol: TCP
+status:
+  conditions:
+    - type: Synced
+      status: "True"
+    - type: Ready
+      status: "False"
+`
+
+func TestExtractFieldOwnership(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	require.NoError(t, yaml.Unmarshal([]byte(managedFieldsTestObject), &obj.Object))
+
+	owners, err := ExtractFieldOwnership(obj)
+	require.NoError(t, err)
+	require.Len(t, owners, 3)
+
+	// Most recent write first
+	assert.Equal(t, "widget-operator", owners[0].Manager)
+	assert.Equal(t, "Update", owners[0].Operation)
+	assert.Equal(t, "status", owners[0].Subresource)
+	assert.True(t, time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC).Equal(owners[0].Time))
+	assert.Equal(t, []string{
+		"status.conditions[1]",
+		"status.conditions[1].status",
+		"status.conditions[type=Gone]",
+	}, owners[0].Paths)
+
+	assert.Equal(t, "kubectl-edit", owners[1].Manager)
+	assert.Equal(t, []string{"spec.replicas", "spec.tags[1]"}, owners[1].Paths)
+
+	assert.Equal(t, "argocd-controller", owners[2].Manager)
+	assert.Equal(t, "Apply", owners[2].Operation)
+	assert.Equal(t, []string{
+		"metadata.labels.app",
+		"spec.ports[1]",
+		"spec.ports[1].port",
+		"spec.replicas",
+	}, owners[2].Paths)
+
+	byPath := FieldOwners(owners)
+	assert.Equal(t, []string{"kubectl-edit", "argocd-controller"}, byPath["spec.replicas"])
+	assert.Equal(t, []string{"argocd-controller"}, byPath["metadata.labels.app"])
+	assert.Empty(t, byPath["spec"])
+}
+
+func TestExtractFieldOwnership_Invalid(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"managedFields": []interface{}{
+				map[string]interface{}{"manager": "broken", "fieldsType": "FieldsV1", "fieldsV1": map[string]interface{}{"k:{broken": map[string]interface{}{}}},
+			},
+		},
+	}}
+
+	_, err := ExtractFieldOwnership(obj)
+	assert.ErrorContains(t, err, "broken")
+}
@@ -0,0 +1,163 @@
+package k8s
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// ReadManifests reads all objects from a YAML or JSON file, or from all such files in a directory.
+// Multi-document files and List objects are split into their items.
+func ReadManifests(path string) ([]*unstructured.Unstructured, error) {
+	files, err := ManifestFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, file := range files {
+		objs, err := ReadManifestFile(file)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, objs...)
+	}
+	return objects, nil
+}
+
+// ManifestFiles returns path itself if it is a file, or all YAML and JSON files below a directory
+func ManifestFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && isManifestFile(p) {
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// ReadManifestFile reads all objects from a single file
+func ReadManifestFile(file string) ([]*unstructured.Unstructured, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := DecodeManifests(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	return objects, nil
+}
+
+// DecodeManifests splits a multi-document YAML or JSON stream into objects
+func DecodeManifests(data []byte) ([]*unstructured.Unstructured, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+
+	var objects []*unstructured.Unstructured
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Decode like the API server does, integers become int64 instead of float64
+		jsonDoc, err := yaml.YAMLToJSON(doc)
+		if err != nil {
+			return nil, err
+		}
+		obj := &unstructured.Unstructured{}
+		if err := utiljson.Unmarshal(jsonDoc, &obj.Object); err != nil {
+			return nil, err
+		}
+		if len(obj.Object) == 0 {
+			// Empty document, e.g. a trailing "---"
+			continue
+		}
+
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, err
+			}
+			for i := range list.Items {
+				objects = append(objects, &list.Items[i])
+			}
+			continue
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// LoadCRDs reads all CustomResourceDefinitions from a file or directory
+func LoadCRDs(path string) ([]*apiextensionsv1.CustomResourceDefinition, error) {
+	objects, err := ReadManifests(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var crds []*apiextensionsv1.CustomResourceDefinition
+	for _, obj := range objects {
+		if obj.GetKind() != "CustomResourceDefinition" || obj.GroupVersionKind().Group != apiextensionsv1.GroupName {
+			continue
+		}
+		crd, err := ToCRD(obj)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CRD %s: %w", obj.GetName(), err)
+		}
+		crds = append(crds, crd)
+	}
+	return crds, nil
+}
+
+// ToCRD converts an unstructured object into a typed CRD
+func ToCRD(obj *unstructured.Unstructured) (*apiextensionsv1.CustomResourceDefinition, error) {
+	if obj.GetAPIVersion() != apiextensionsv1.SchemeGroupVersion.String() {
+		return nil, fmt.Errorf("unsupported apiVersion %s, only %s is supported", obj.GetAPIVersion(), apiextensionsv1.SchemeGroupVersion)
+	}
+
+	data, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return nil, err
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(data, crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+// isManifestFile returns true for files that may contain Kubernetes manifests
+func isManifestFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCRDManifest = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: foo
+---
+`
+
+func TestDecodeManifests(t *testing.T) {
+	objs, err := DecodeManifests([]byte(testCRDManifest))
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+	assert.Equal(t, "CustomResourceDefinition", objs[0].GetKind())
+	assert.Equal(t, "foo", objs[1].GetName())
+
+	list := `{"apiVersion": "v1", "kind": "List", "items": [
+		{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "a"}},
+		{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "b"}}
+	]}`
+	objs, err = DecodeManifests([]byte(list))
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+	assert.Equal(t, "b", objs[1].GetName())
+
+	_, err = DecodeManifests([]byte("foo: [unclosed"))
+	assert.Error(t, err)
+}
+
+func TestLoadCRDs(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "crds.yaml"), []byte(testCRDManifest), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# not a manifest"), 0644))
+
+	crds, err := LoadCRDs(dir)
+	require.NoError(t, err)
+	require.Len(t, crds, 1)
+	assert.Equal(t, "widgets.example.com", crds[0].Name)
+	require.Len(t, crds[0].Spec.Versions, 1)
+	assert.NotNil(t, crds[0].Spec.Versions[0].Schema.OpenAPIV3Schema)
+
+	_, err = LoadCRDs(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
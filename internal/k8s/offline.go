@@ -0,0 +1,176 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pteich/crdlens/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+// ReadOnlyMessage is shown when a write action is triggered on an offline client
+const ReadOnlyMessage = "Read-only: resources were loaded from files"
+
+// NewOfflineClient creates a read-only client backed by in-memory fake clients. They serve
+// the CRDs, custom resources, namespaces and events found in the given files and directories.
+// Objects of the CoreKinds are served as well, so owner trees include them.
+func NewOfflineClient(cfg *config.Config, sources []string) (*Client, error) {
+	health, err := NewHealthRules(cfg.HealthRules())
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStatusEngine(cfg.StatusEngine); err != nil {
+		return nil, err
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, source := range sources {
+		objs, err := ReadManifests(source)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, objs...)
+	}
+
+	namespace := cfg.Namespace
+	if namespace == "" {
+		namespace = "default"
+		cfg.Namespace = namespace
+	}
+
+	crds := make(map[schema.GroupKind]*apiextensionsv1.CustomResourceDefinition)
+	var crdObjects, kubeObjects []runtime.Object
+	var resources []*unstructured.Unstructured
+	namespaces := map[string]bool{namespace: true}
+
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		switch {
+		case gvk.Group == apiextensionsv1.GroupName && gvk.Kind == "CustomResourceDefinition":
+			crd, err := ToCRD(obj)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CRD %s: %w", obj.GetName(), err)
+			}
+			crds[schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}] = crd
+			crdObjects = append(crdObjects, crd)
+
+		case gvk.Group == "" && gvk.Version == "v1" && (gvk.Kind == "Namespace" || gvk.Kind == "Event"):
+			typed, err := toTypedCoreObject(obj)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %s: %w", gvk.Kind, obj.GetName(), err)
+			}
+			if gvk.Kind == "Namespace" {
+				namespaces[obj.GetName()] = false // Already part of the objects
+			}
+			kubeObjects = append(kubeObjects, typed)
+
+		default:
+			resources = append(resources, obj)
+		}
+	}
+
+	// Built-in kinds are only served for the owner tree
+	listKinds := make(map[schema.GroupVersionResource]string)
+	coreKinds := make(map[schema.GroupVersionKind]KindResource)
+	for _, k := range CoreKinds {
+		listKinds[k.GVR] = k.Kind + "List"
+		coreKinds[k.GVR.GroupVersion().WithKind(k.Kind)] = k
+	}
+	for _, crd := range crds {
+		for _, v := range crd.Spec.Versions {
+			gvr := schema.GroupVersionResource{Group: crd.Spec.Group, Version: v.Name, Resource: crd.Spec.Names.Plural}
+			listKinds[gvr] = crd.Spec.Names.Kind + "List"
+		}
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+
+	for _, obj := range resources {
+		if obj.GetUID() == "" {
+			obj.SetUID(uuid.NewUUID())
+		}
+
+		if core, ok := coreKinds[obj.GroupVersionKind()]; ok {
+			if !core.Namespaced {
+				obj.SetNamespace("")
+			} else if obj.GetNamespace() == "" {
+				obj.SetNamespace(namespace)
+			}
+			if err := dynamicClient.Tracker().Create(core.GVR, obj, obj.GetNamespace()); err != nil {
+				return nil, fmt.Errorf("failed to load %s %s: %w", obj.GetKind(), obj.GetName(), err)
+			}
+			continue
+		}
+
+		crd, ok := crds[obj.GroupVersionKind().GroupKind()]
+		if !ok {
+			// Other built-in kinds and resources without a CRD can't be browsed
+			continue
+		}
+
+		if crd.Spec.Scope == apiextensionsv1.NamespaceScoped {
+			if obj.GetNamespace() == "" {
+				obj.SetNamespace(namespace)
+			}
+			if _, seen := namespaces[obj.GetNamespace()]; !seen {
+				namespaces[obj.GetNamespace()] = true
+			}
+		} else {
+			obj.SetNamespace("")
+		}
+
+		// There is no conversion webhook, so resources are served as is by all
+		// versions, just like with the None conversion strategy
+		for _, v := range crd.Spec.Versions {
+			if !v.Served {
+				continue
+			}
+			served := obj.DeepCopy()
+			served.SetAPIVersion(crd.Spec.Group + "/" + v.Name)
+			gvr := schema.GroupVersionResource{Group: crd.Spec.Group, Version: v.Name, Resource: crd.Spec.Names.Plural}
+			if err := dynamicClient.Tracker().Create(gvr, served, served.GetNamespace()); err != nil {
+				return nil, fmt.Errorf("failed to load %s %s: %w", obj.GetKind(), obj.GetName(), err)
+			}
+		}
+	}
+
+	for ns, missing := range namespaces {
+		if missing {
+			kubeObjects = append(kubeObjects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}})
+		}
+	}
+
+	return &Client{
+		KubeClient:          kubefake.NewSimpleClientset(kubeObjects...),
+		DynamicClient:       dynamicClient,
+		ApiextensionsClient: apiextensionsfake.NewSimpleClientset(crdObjects...),
+		Context:             "offline: " + strings.Join(sources, ", "),
+		Namespace:           namespace,
+		ReadOnly:            true,
+		Health:              health,
+		StatusEngine:        cfg.StatusEngine,
+	}, nil
+}
+
+// toTypedCoreObject converts namespaces and events for the fake kubernetes client
+func toTypedCoreObject(obj *unstructured.Unstructured) (runtime.Object, error) {
+	var typed runtime.Object
+	switch obj.GetKind() {
+	case "Namespace":
+		typed = &corev1.Namespace{}
+	default:
+		typed = &corev1.Event{}
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), typed); err != nil {
+		return nil, err
+	}
+	return typed, nil
+}
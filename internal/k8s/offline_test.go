@@ -0,0 +1,129 @@
+package k8s
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pteich/crdlens/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const offlineTestManifests = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  scope: Namespaced
+  names:
+    kind: Widget
+    plural: widgets
+  versions:
+    - name: v1beta1
+      served: true
+      storage: false
+      schema:
+        openAPIV3Schema:
+          type: object
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: first
+spec:
+  size: 1
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: second
+  namespace: team-a
+spec:
+  size: 2
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+`
+
+func TestNewOfflineClient(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "widgets.yaml"), []byte(offlineTestManifests), 0o644))
+
+	cfg := config.DefaultConfig()
+	cfg.FromDir = dir
+	client, err := NewClient(cfg)
+	require.NoError(t, err)
+	assert.True(t, client.ReadOnly)
+	assert.Equal(t, "default", client.Namespace)
+	assert.Contains(t, client.Context, dir)
+
+	ctx := context.Background()
+	crds, err := client.Discovery().ListCRDs(ctx)
+	require.NoError(t, err)
+	require.Len(t, crds, 1)
+	assert.Equal(t, "Widget", crds[0].Kind)
+
+	resources, err := client.Dynamic().ListResources(ctx, crds[0].GVR, "")
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	for _, res := range resources {
+		assert.NotEmpty(t, res.UID, "resources need a UID for the views")
+	}
+
+	resources, err = client.Dynamic().ListResources(ctx, crds[0].GVR, "default")
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "first", resources[0].Name)
+
+	// Other served versions return the same resources
+	oldGVR := schema.GroupVersionResource{Group: "example.com", Version: "v1beta1", Resource: "widgets"}
+	res, err := client.Dynamic().GetResource(ctx, oldGVR, "team-a", "second")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/v1beta1", res.Raw.GetAPIVersion())
+
+	namespaces, err := client.ListNamespaces(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"default", "team-a"}, namespaces)
+}
+
+func TestNewOfflineClient_Generation(t *testing.T) {
+	dir := t.TempDir()
+	manifests := offlineTestManifests + `---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: drifting
+  namespace: default
+  generation: 5
+spec:
+  size: 3
+status:
+  observedGeneration: 3
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "widgets.yaml"), []byte(manifests), 0o644))
+
+	cfg := config.DefaultConfig()
+	cfg.FromDir = dir
+	client, err := NewClient(cfg)
+	require.NoError(t, err)
+
+	gvr := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	res, err := client.Dynamic().GetResource(context.Background(), gvr, "default", "drifting")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), res.Generation, "integers from YAML must not be decoded as float64")
+	assert.Equal(t, int64(3), res.ObservedGeneration)
+	assert.Equal(t, int64(2), res.Drift())
+	assert.True(t, res.IsReconciling())
+}
@@ -0,0 +1,282 @@
+package k8s
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pteich/crdlens/internal/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+)
+
+const (
+	// maxOwnerDepth stops walking up owner references in case of cycles
+	maxOwnerDepth = 16
+	// ownerListWorkers is the number of kinds listed in parallel while searching for children
+	ownerListWorkers = 8
+)
+
+// KindResource maps a kind to the resource used to read it
+type KindResource struct {
+	Kind       string
+	GVR        schema.GroupVersionResource
+	Namespaced bool
+}
+
+// CoreKinds are the built-in kinds that are searched for children of a resource.
+// Controllers usually create these for custom resources.
+var CoreKinds = []KindResource{
+	{Kind: "Pod", GVR: schema.GroupVersionResource{Version: "v1", Resource: "pods"}, Namespaced: true},
+	{Kind: "Service", GVR: schema.GroupVersionResource{Version: "v1", Resource: "services"}, Namespaced: true},
+	{Kind: "ConfigMap", GVR: schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, Namespaced: true},
+	{Kind: "Secret", GVR: schema.GroupVersionResource{Version: "v1", Resource: "secrets"}, Namespaced: true},
+	{Kind: "ServiceAccount", GVR: schema.GroupVersionResource{Version: "v1", Resource: "serviceaccounts"}, Namespaced: true},
+	{Kind: "PersistentVolumeClaim", GVR: schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"}, Namespaced: true},
+	{Kind: "Deployment", GVR: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, Namespaced: true},
+	{Kind: "ReplicaSet", GVR: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}, Namespaced: true},
+	{Kind: "StatefulSet", GVR: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}, Namespaced: true},
+	{Kind: "DaemonSet", GVR: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"}, Namespaced: true},
+	{Kind: "Job", GVR: schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}, Namespaced: true},
+	{Kind: "CronJob", GVR: schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}, Namespaced: true},
+	{Kind: "Ingress", GVR: schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}, Namespaced: true},
+	{Kind: "NetworkPolicy", GVR: schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"}, Namespaced: true},
+	{Kind: "EndpointSlice", GVR: schema.GroupVersionResource{Group: "discovery.k8s.io", Version: "v1", Resource: "endpointslices"}, Namespaced: true},
+	{Kind: "PodDisruptionBudget", GVR: schema.GroupVersionResource{Group: "policy", Version: "v1", Resource: "poddisruptionbudgets"}, Namespaced: true},
+	{Kind: "HorizontalPodAutoscaler", GVR: schema.GroupVersionResource{Group: "autoscaling", Version: "v2", Resource: "horizontalpodautoscalers"}, Namespaced: true},
+	{Kind: "Role", GVR: schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "roles"}, Namespaced: true},
+	{Kind: "RoleBinding", GVR: schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "rolebindings"}, Namespaced: true},
+	{Kind: "ClusterRole", GVR: schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles"}},
+	{Kind: "ClusterRoleBinding", GVR: schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterrolebindings"}},
+	{Kind: "PersistentVolume", GVR: schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumes"}},
+}
+
+// OwnerTreeNode is a resource in an ownership tree
+type OwnerTreeNode struct {
+	Resource types.Resource
+	Children []*OwnerTreeNode
+	Missing  bool // The owner is referenced but could not be read, only name, kind and UID are set
+}
+
+// OwnerTree is the ownership tree a resource belongs to
+type OwnerTree struct {
+	Root    *OwnerTreeNode
+	Skipped int // Kinds that could not be listed, e.g. because of missing permissions
+}
+
+// Find returns the node of the resource with the given UID
+func (t *OwnerTree) Find(uid string) *OwnerTreeNode {
+	var find func(n *OwnerTreeNode) *OwnerTreeNode
+	find = func(n *OwnerTreeNode) *OwnerTreeNode {
+		if n.Resource.UID == uid {
+			return n
+		}
+		for _, c := range n.Children {
+			if found := find(c); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	if t.Root == nil {
+		return nil
+	}
+	return find(t.Root)
+}
+
+// OwnerService builds ownership trees from ownerReferences
+type OwnerService struct {
+	discovery *DiscoveryService
+	dynamic   *DynamicService
+}
+
+// NewOwnerService creates a new OwnerService
+func NewOwnerService(discovery *DiscoveryService, dynamic *DynamicService) *OwnerService {
+	return &OwnerService{
+		discovery: discovery,
+		dynamic:   dynamic,
+	}
+}
+
+// BuildTree walks the ownerReferences of res up to the root owner and collects everything
+// that is owned by the root, directly or indirectly. Children are searched in all CRDs and
+// the CoreKinds. Kinds that can't be listed are skipped and counted in OwnerTree.Skipped.
+func (s *OwnerService) BuildTree(ctx context.Context, res types.Resource) (*OwnerTree, error) {
+	kinds, err := s.kinds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, missing := s.owners(ctx, res, kinds)
+	top := chain[len(chain)-1]
+
+	// Cluster-scoped resources can't be owned by namespaced ones, so children
+	// of a namespaced root are always in the same namespace
+	var listKinds []KindResource
+	for _, k := range kinds {
+		if top.Namespace == "" || k.Namespaced {
+			listKinds = append(listKinds, k)
+		}
+	}
+
+	byOwner, skipped := s.listOwned(ctx, listKinds, top.Namespace)
+
+	// The chain is added in case its kinds could not be listed
+	seen := make(map[string]bool)
+	for _, children := range byOwner {
+		for _, c := range children {
+			seen[c.UID] = true
+		}
+	}
+	for _, r := range chain[:len(chain)-1] {
+		if !seen[r.UID] {
+			for _, ref := range r.Raw.GetOwnerReferences() {
+				byOwner[string(ref.UID)] = append(byOwner[string(ref.UID)], r)
+			}
+		}
+	}
+
+	root := buildOwnerNode(top, byOwner, make(map[string]bool))
+	if missing != nil {
+		root = &OwnerTreeNode{Resource: *missing, Missing: true, Children: []*OwnerTreeNode{root}}
+	}
+	return &OwnerTree{Root: root, Skipped: skipped}, nil
+}
+
+// kinds returns the kinds of all CRDs and the CoreKinds, indexed by group and kind
+func (s *OwnerService) kinds(ctx context.Context) (map[schema.GroupKind]KindResource, error) {
+	crds, err := s.discovery.ListCRDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	kinds := make(map[schema.GroupKind]KindResource, len(crds)+len(CoreKinds))
+	for _, k := range CoreKinds {
+		kinds[schema.GroupKind{Group: k.GVR.Group, Kind: k.Kind}] = k
+	}
+	for _, crd := range crds {
+		kinds[schema.GroupKind{Group: crd.Group, Kind: crd.Kind}] = KindResource{
+			Kind:       crd.Kind,
+			GVR:        crd.GVR,
+			Namespaced: crd.Scope == "Namespaced",
+		}
+	}
+	return kinds, nil
+}
+
+// owners returns res followed by its owners up to the root owner. The controller reference
+// is followed, or the first owner if there is no controller. If an owner can't be read, it
+// is returned as missing resource with the data of the owner reference.
+func (s *OwnerService) owners(ctx context.Context, res types.Resource, kinds map[schema.GroupKind]KindResource) ([]types.Resource, *types.Resource) {
+	chain := []types.Resource{res}
+	seen := map[string]bool{res.UID: true}
+
+	current := res
+	for len(chain) < maxOwnerDepth && current.Raw != nil {
+		ref := metav1.GetControllerOfNoCopy(current.Raw)
+		if ref == nil {
+			refs := current.Raw.GetOwnerReferences()
+			if len(refs) == 0 {
+				break
+			}
+			ref = &refs[0]
+		}
+		if seen[string(ref.UID)] {
+			break
+		}
+		seen[string(ref.UID)] = true
+
+		missing := &types.Resource{Name: ref.Name, Kind: ref.Kind, UID: string(ref.UID),
+			KStatus: status.NotFoundStatus.String(), KStatusMessage: "Owner not found"}
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			return chain, missing
+		}
+		kind, ok := kinds[schema.GroupKind{Group: gv.Group, Kind: ref.Kind}]
+		if !ok {
+			return chain, missing
+		}
+
+		namespace := ""
+		if kind.Namespaced {
+			namespace = current.Namespace
+			missing.Namespace = namespace
+		}
+		missing.GVR = kind.GVR
+
+		owner, err := s.dynamic.GetResource(ctx, kind.GVR, namespace, ref.Name)
+		if err != nil || owner.UID != string(ref.UID) {
+			// Deleted, recreated with the same name or not readable
+			return chain, missing
+		}
+		chain = append(chain, *owner)
+		current = *owner
+	}
+	return chain, nil
+}
+
+// listOwned lists all resources of the given kinds and indexes them by the UIDs of their owners
+func (s *OwnerService) listOwned(ctx context.Context, kinds []KindResource, namespace string) (map[string][]types.Resource, int) {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		skipped int
+		byOwner = make(map[string][]types.Resource)
+		sem     = make(chan struct{}, ownerListWorkers)
+	)
+
+	for _, kind := range kinds {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(kind KindResource) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			ns := namespace
+			if !kind.Namespaced {
+				ns = ""
+			}
+			resources, err := s.dynamic.ListAllResources(ctx, kind.GVR, ns, ListResourcesOptions{})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				skipped++
+				return
+			}
+			for _, r := range resources {
+				for _, ref := range r.Raw.GetOwnerReferences() {
+					byOwner[string(ref.UID)] = append(byOwner[string(ref.UID)], r)
+				}
+			}
+		}(kind)
+	}
+	wg.Wait()
+
+	return byOwner, skipped
+}
+
+// buildOwnerNode builds the subtree of res, children are sorted by kind and name
+func buildOwnerNode(res types.Resource, byOwner map[string][]types.Resource, visited map[string]bool) *OwnerTreeNode {
+	visited[res.UID] = true
+	node := &OwnerTreeNode{Resource: res}
+
+	children := byOwner[res.UID]
+	sort.Slice(children, func(i, j int) bool {
+		if children[i].Kind != children[j].Kind {
+			return children[i].Kind < children[j].Kind
+		}
+		if children[i].Namespace != children[j].Namespace {
+			return children[i].Namespace < children[j].Namespace
+		}
+		return children[i].Name < children[j].Name
+	})
+	for _, c := range children {
+		if visited[c.UID] {
+			continue
+		}
+		node.Children = append(node.Children, buildOwnerNode(c, byOwner, visited))
+	}
+	return node
+}
@@ -0,0 +1,153 @@
+package k8s
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pteich/crdlens/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const ownerTestManifests = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: apps.example.com
+spec:
+  group: example.com
+  scope: Namespaced
+  names:
+    kind: App
+    plural: apps
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+---
+apiVersion: example.com/v1
+kind: App
+metadata:
+  name: web
+  uid: app-uid
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  uid: deploy-uid
+  ownerReferences:
+    - apiVersion: example.com/v1
+      kind: App
+      name: web
+      uid: app-uid
+      controller: true
+---
+apiVersion: apps/v1
+kind: ReplicaSet
+metadata:
+  name: web-7f9c
+  uid: rs-uid
+  ownerReferences:
+    - apiVersion: apps/v1
+      kind: Deployment
+      name: web
+      uid: deploy-uid
+      controller: true
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-7f9c-abcde
+  uid: pod-uid
+  ownerReferences:
+    - apiVersion: apps/v1
+      kind: ReplicaSet
+      name: web-7f9c
+      uid: rs-uid
+      controller: true
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-config
+  uid: cm-uid
+  ownerReferences:
+    - apiVersion: example.com/v1
+      kind: App
+      name: web
+      uid: app-uid
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: orphan
+  uid: orphan-uid
+  ownerReferences:
+    - apiVersion: example.com/v1
+      kind: App
+      name: deleted
+      uid: deleted-uid
+`
+
+func newOwnerTestClient(t *testing.T) *Client {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.yaml"), []byte(ownerTestManifests), 0o644))
+
+	cfg := config.DefaultConfig()
+	cfg.FromDir = dir
+	client, err := NewClient(cfg)
+	require.NoError(t, err)
+	return client
+}
+
+func TestOwnerService_BuildTree(t *testing.T) {
+	client := newOwnerTestClient(t)
+	ctx := context.Background()
+
+	podGVR := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	pod, err := client.Dynamic().GetResource(ctx, podGVR, "default", "web-7f9c-abcde")
+	require.NoError(t, err)
+
+	tree, err := client.Owners().BuildTree(ctx, *pod)
+	require.NoError(t, err)
+	assert.Zero(t, tree.Skipped)
+
+	root := tree.Root
+	assert.False(t, root.Missing)
+	assert.Equal(t, "App", root.Resource.Kind)
+	assert.Equal(t, "web", root.Resource.Name)
+
+	// Children are sorted by kind
+	require.Len(t, root.Children, 2)
+	assert.Equal(t, "ConfigMap", root.Children[0].Resource.Kind)
+	assert.Equal(t, "Deployment", root.Children[1].Resource.Kind)
+
+	node := tree.Find("pod-uid")
+	require.NotNil(t, node)
+	assert.Equal(t, "web-7f9c-abcde", node.Resource.Name)
+	assert.Nil(t, tree.Find("orphan-uid"), "resources of other owners are not part of the tree")
+}
+
+func TestOwnerService_BuildTree_MissingOwner(t *testing.T) {
+	client := newOwnerTestClient(t)
+	ctx := context.Background()
+
+	cmGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	cm, err := client.Dynamic().GetResource(ctx, cmGVR, "default", "orphan")
+	require.NoError(t, err)
+
+	tree, err := client.Owners().BuildTree(ctx, *cm)
+	require.NoError(t, err)
+
+	assert.True(t, tree.Root.Missing)
+	assert.Equal(t, "deleted", tree.Root.Resource.Name)
+	assert.Equal(t, "deleted-uid", tree.Root.Resource.UID)
+	require.Len(t, tree.Root.Children, 1)
+	assert.Equal(t, "orphan", tree.Root.Children[0].Resource.Name)
+}
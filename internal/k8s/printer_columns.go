@@ -0,0 +1,84 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pteich/crdlens/internal/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// DefaultPrinterColumns are used by the API server for CRDs without additionalPrinterColumns
+var DefaultPrinterColumns = []types.PrinterColumn{
+	{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+}
+
+// PrinterColumnValue evaluates the JSONPath of a printer column against obj and formats
+// the result by the column type like kubectl get does. Missing values result in "".
+func PrinterColumnValue(obj *unstructured.Unstructured, col types.PrinterColumn, now time.Time) string {
+	if obj == nil {
+		return ""
+	}
+
+	jp := jsonpath.New(col.Name).AllowMissingKeys(true)
+	if err := jp.Parse(fmt.Sprintf("{%s}", col.JSONPath)); err != nil {
+		return "<invalid path>"
+	}
+	results, err := jp.FindResults(obj.Object)
+	if err != nil {
+		return "<error>"
+	}
+
+	var values []string
+	for _, result := range results {
+		for _, v := range result {
+			if !v.IsValid() || !v.CanInterface() || v.Interface() == nil {
+				continue
+			}
+			values = append(values, formatPrinterValue(v.Interface(), col.Type, now))
+		}
+	}
+	return strings.Join(values, ",")
+}
+
+// formatPrinterValue formats a single value by the printer column type
+func formatPrinterValue(value interface{}, colType string, now time.Time) string {
+	switch colType {
+	case "date":
+		s, ok := value.(string)
+		if !ok {
+			break
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return s
+		}
+		return duration.HumanDuration(now.Sub(t))
+	case "integer":
+		switch n := value.(type) {
+		case int64:
+			return fmt.Sprintf("%d", n)
+		case float64:
+			return fmt.Sprintf("%d", int64(n))
+		}
+	case "boolean":
+		if b, ok := value.(bool); ok {
+			return fmt.Sprintf("%t", b)
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		// Same as kubectl, complex values are printed as JSON
+		if data, err := json.Marshal(v); err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprint(value)
+}
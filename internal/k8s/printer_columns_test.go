@@ -0,0 +1,49 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pteich/crdlens/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrinterColumnValue(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	obj := newTestObject("example.com/v1", "Widget", "example")
+	obj.Object["spec"] = map[string]interface{}{
+		"replicas": int64(3),
+		"ratio":    0.5,
+		"paused":   true,
+		"selector": map[string]interface{}{"app": "web"},
+	}
+	obj.Object["status"] = map[string]interface{}{
+		"lastSync": "2024-05-01T11:55:00Z",
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "True"},
+			map[string]interface{}{"type": "Synced", "status": "False"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		col  types.PrinterColumn
+		want string
+	}{
+		{"integer", types.PrinterColumn{Type: "integer", JSONPath: ".spec.replicas"}, "3"},
+		{"number", types.PrinterColumn{Type: "number", JSONPath: ".spec.ratio"}, "0.5"},
+		{"boolean", types.PrinterColumn{Type: "boolean", JSONPath: ".spec.paused"}, "true"},
+		{"date", types.PrinterColumn{Type: "date", JSONPath: ".status.lastSync"}, "5m"},
+		{"filter", types.PrinterColumn{Type: "string", JSONPath: `.status.conditions[?(@.type=="Ready")].status`}, "True"},
+		{"list", types.PrinterColumn{Type: "string", JSONPath: ".status.conditions[*].type"}, "Ready,Synced"},
+		{"object", types.PrinterColumn{Type: "string", JSONPath: ".spec.selector"}, `{"app":"web"}`},
+		{"missing", types.PrinterColumn{Type: "string", JSONPath: ".spec.missing"}, ""},
+		{"invalid", types.PrinterColumn{Type: "string", JSONPath: ".spec[["}, "<invalid path>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.col.Name = tt.name
+			assert.Equal(t, tt.want, PrinterColumnValue(obj, tt.col, now))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pteich/crdlens/internal/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// Annotations understood by well-known controllers to trigger a reconciliation
+const (
+	FluxReconcileAnnotation = "reconcile.fluxcd.io/requestedAt"
+	ArgoCDRefreshAnnotation = "argocd.argoproj.io/refresh"
+)
+
+// Controller families that can be asked to reconcile
+const (
+	ControllerFlux    = "flux"
+	ControllerArgoCD  = "argocd"
+	ControllerGeneric = "generic"
+)
+
+// ReconcileRequest describes the annotation patch that asks a controller to reconcile
+type ReconcileRequest struct {
+	Controller  string
+	Annotation  string
+	Value       string
+	RequestedAt time.Time
+}
+
+// DetectControllerFamily guesses the controller family from the managing controller and the API group
+func DetectControllerFamily(res types.Resource) string {
+	manager := toLower(res.ControllerManager)
+	switch ShortenManagerName(res.ControllerManager) {
+	case "helm", "kustomize", "flux-src":
+		return ControllerFlux
+	case "argocd":
+		return ControllerArgoCD
+	}
+
+	switch {
+	case contains(manager, "flux") || strings.HasSuffix(res.GVR.Group, "toolkit.fluxcd.io"):
+		return ControllerFlux
+	case contains(manager, "argocd") || (res.GVR.Group == "argoproj.io" && res.Kind == "Application"):
+		return ControllerArgoCD
+	}
+	return ControllerGeneric
+}
+
+// NewReconcileRequest picks the right annotation for the controller managing res.
+// genericAnnotation is used for controllers without a known convention.
+func NewReconcileRequest(res types.Resource, genericAnnotation string, now time.Time) ReconcileRequest {
+	req := ReconcileRequest{
+		Controller:  DetectControllerFamily(res),
+		Annotation:  genericAnnotation,
+		Value:       now.UTC().Format(time.RFC3339Nano),
+		RequestedAt: now,
+	}
+
+	switch req.Controller {
+	case ControllerFlux:
+		req.Annotation = FluxReconcileAnnotation
+	case ControllerArgoCD:
+		req.Annotation = ArgoCDRefreshAnnotation
+		req.Value = "normal"
+	}
+	return req
+}
+
+// RequestReconcile patches the reconcile annotation onto the resource
+func (s *DynamicService) RequestReconcile(ctx context.Context, res types.Resource, req ReconcileRequest) (*types.Resource, error) {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{req.Annotation: req.Value},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Patch(ctx, res.GVR, res.Namespace, res.Name, k8stypes.MergePatchType, patch)
+}
+
+// ReconcileAcknowledged reports whether the controller has handled the request
+func ReconcileAcknowledged(res types.Resource, req ReconcileRequest) bool {
+	if res.Raw == nil {
+		return false
+	}
+
+	switch req.Controller {
+	case ControllerFlux:
+		// Flux records the handled token in status.lastHandledReconcileAt
+		handled, _, _ := unstructured.NestedString(res.Raw.Object, "status", "lastHandledReconcileAt")
+		return handled == req.Value
+	case ControllerArgoCD:
+		// Argo CD removes the refresh annotation once the refresh is done
+		_, found := res.Raw.GetAnnotations()[ArgoCDRefreshAnnotation]
+		return !found
+	default:
+		// Without a convention the best signal is a status write after the request.
+		// managedFields timestamps only have second precision.
+		return !res.LastStatusWrite.Before(req.RequestedAt.Truncate(time.Second))
+	}
+}
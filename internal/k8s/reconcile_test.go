@@ -0,0 +1,127 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/pteich/crdlens/internal/types"
+)
+
+func TestDetectControllerFamily(t *testing.T) {
+	tests := []struct {
+		name     string
+		res      types.Resource
+		expected string
+	}{
+		{
+			name:     "flux manager",
+			res:      types.Resource{ControllerManager: "kustomize-controller"},
+			expected: ControllerFlux,
+		},
+		{
+			name: "flux group",
+			res: types.Resource{
+				GVR: schema.GroupVersionResource{Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "gitrepositories"},
+			},
+			expected: ControllerFlux,
+		},
+		{
+			name:     "argocd manager",
+			res:      types.Resource{ControllerManager: "argocd-application-controller"},
+			expected: ControllerArgoCD,
+		},
+		{
+			name: "argocd application",
+			res: types.Resource{
+				Kind: "Application",
+				GVR:  schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "applications"},
+			},
+			expected: ControllerArgoCD,
+		},
+		{
+			name:     "unknown controller",
+			res:      types.Resource{ControllerManager: "my-operator"},
+			expected: ControllerGeneric,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DetectControllerFamily(tt.res))
+		})
+	}
+}
+
+func TestNewReconcileRequest(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	req := NewReconcileRequest(types.Resource{ControllerManager: "helm-controller"}, "example.com/reconcile", now)
+	assert.Equal(t, ControllerFlux, req.Controller)
+	assert.Equal(t, FluxReconcileAnnotation, req.Annotation)
+	assert.Equal(t, "2025-01-02T03:04:05Z", req.Value)
+
+	req = NewReconcileRequest(types.Resource{ControllerManager: "argocd-server"}, "example.com/reconcile", now)
+	assert.Equal(t, ArgoCDRefreshAnnotation, req.Annotation)
+	assert.Equal(t, "normal", req.Value)
+
+	req = NewReconcileRequest(types.Resource{ControllerManager: "my-operator"}, "example.com/reconcile", now)
+	assert.Equal(t, ControllerGeneric, req.Controller)
+	assert.Equal(t, "example.com/reconcile", req.Annotation)
+	assert.Equal(t, now, req.RequestedAt)
+}
+
+func TestReconcileAcknowledged(t *testing.T) {
+	now := time.Now()
+
+	t.Run("flux", func(t *testing.T) {
+		req := ReconcileRequest{Controller: ControllerFlux, Annotation: FluxReconcileAnnotation, Value: "token", RequestedAt: now}
+		obj := newTestWidget("foo", "1")
+		res := types.Resource{Raw: obj}
+		assert.False(t, ReconcileAcknowledged(res, req))
+
+		require.NoError(t, unstructured.SetNestedField(obj.Object, "token", "status", "lastHandledReconcileAt"))
+		assert.True(t, ReconcileAcknowledged(res, req))
+	})
+
+	t.Run("argocd", func(t *testing.T) {
+		req := ReconcileRequest{Controller: ControllerArgoCD, Annotation: ArgoCDRefreshAnnotation, Value: "normal", RequestedAt: now}
+		obj := newTestWidget("foo", "1")
+		obj.SetAnnotations(map[string]string{ArgoCDRefreshAnnotation: "normal"})
+		res := types.Resource{Raw: obj}
+		assert.False(t, ReconcileAcknowledged(res, req))
+
+		obj.SetAnnotations(nil)
+		assert.True(t, ReconcileAcknowledged(res, req))
+	})
+
+	t.Run("generic", func(t *testing.T) {
+		req := ReconcileRequest{Controller: ControllerGeneric, RequestedAt: now}
+		res := types.Resource{Raw: newTestWidget("foo", "1"), LastStatusWrite: now.Add(-time.Minute)}
+		assert.False(t, ReconcileAcknowledged(res, req))
+
+		res.LastStatusWrite = now.Truncate(time.Second)
+		assert.True(t, ReconcileAcknowledged(res, req))
+	})
+
+	t.Run("no object", func(t *testing.T) {
+		assert.False(t, ReconcileAcknowledged(types.Resource{}, ReconcileRequest{Controller: ControllerGeneric}))
+	})
+}
+
+func TestDynamicService_RequestReconcile(t *testing.T) {
+	svc := NewDynamicService(newTestDynamicClient(newTestWidget("foo", "1")))
+
+	res, err := svc.GetResource(context.Background(), testGVR, "default", "foo")
+	require.NoError(t, err)
+
+	req := NewReconcileRequest(*res, "example.com/reconcile", time.Now())
+	updated, err := svc.RequestReconcile(context.Background(), *res, req)
+	require.NoError(t, err)
+	assert.Equal(t, req.Value, updated.Raw.GetAnnotations()["example.com/reconcile"])
+}
@@ -0,0 +1,196 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pteich/crdlens/internal/types"
+)
+
+const (
+	// DefaultReportConcurrency is the number of CRDs scanned in parallel
+	DefaultReportConcurrency = 4
+	// DefaultReportSilence is how long a reconcile may go without status write before it is reported
+	DefaultReportSilence = 24 * time.Hour
+)
+
+// ReportOptions configures a scan for unhealthy resources
+type ReportOptions struct {
+	Namespace    string        // Empty scans all namespaces
+	Concurrency  int           // CRDs listed in parallel (0 = DefaultReportConcurrency)
+	PageSize     int64         // Resources per page (0 = DefaultPageSize)
+	SilenceAfter time.Duration // Report reconciles without status write for longer than this, 0 disables the check
+}
+
+// UnhealthyResource is a resource found by a report scan together with why it was reported
+type UnhealthyResource struct {
+	CRD      string
+	Resource types.Resource
+	Reasons  []string
+}
+
+// ReportGroup are the unhealthy resources of a CRD in one namespace handled by the same controller
+type ReportGroup struct {
+	CRD        string
+	Namespace  string
+	Controller string
+	Resources  []UnhealthyResource
+}
+
+// Report is the result of scanning all CRDs for unhealthy resources
+type Report struct {
+	GeneratedAt      time.Time
+	Namespace        string
+	ScannedCRDs      int
+	ScannedResources int
+	Failed           map[string]string // CRD name -> error of CRDs that could not be listed
+	Resources        []UnhealthyResource
+}
+
+// Groups returns the unhealthy resources grouped by CRD, namespace and controller
+func (r *Report) Groups() []ReportGroup {
+	var groups []ReportGroup
+	for _, u := range r.Resources {
+		n := len(groups)
+		if n > 0 && groups[n-1].CRD == u.CRD && groups[n-1].Namespace == u.Resource.Namespace &&
+			groups[n-1].Controller == u.Resource.ControllerManager {
+			groups[n-1].Resources = append(groups[n-1].Resources, u)
+			continue
+		}
+		groups = append(groups, ReportGroup{
+			CRD:        u.CRD,
+			Namespace:  u.Resource.Namespace,
+			Controller: u.Resource.ControllerManager,
+			Resources:  []UnhealthyResource{u},
+		})
+	}
+	return groups
+}
+
+// ReportService scans all CRDs for unhealthy resources
+type ReportService struct {
+	discovery *DiscoveryService
+	dynamic   *DynamicService
+}
+
+// NewReportService creates a new ReportService
+func NewReportService(discovery *DiscoveryService, dynamic *DynamicService) *ReportService {
+	return &ReportService{
+		discovery: discovery,
+		dynamic:   dynamic,
+	}
+}
+
+// Walk lists the resources of all CRDs, opts.Concurrency CRDs in parallel, and calls fn
+// with the resources of each CRD or the error listing them. Calls of fn don't overlap.
+// The CRDs are returned in the order of ListCRDs.
+func (s *ReportService) Walk(ctx context.Context, opts ReportOptions, fn func(crd types.CRDInfo, resources []types.Resource, err error)) ([]types.CRDInfo, error) {
+	crds, err := s.discovery.ListCRDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = DefaultReportConcurrency
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, workers)
+	)
+	for _, crd := range crds {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(crd types.CRDInfo) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			ns := opts.Namespace
+			if crd.Scope == "Cluster" {
+				ns = ""
+			}
+			resources, err := s.dynamic.ListAllResources(ctx, crd.GVR, ns, ListResourcesOptions{Limit: opts.PageSize})
+
+			mu.Lock()
+			defer mu.Unlock()
+			fn(crd, resources, err)
+		}(crd)
+	}
+	wg.Wait()
+
+	return crds, nil
+}
+
+// Scan lists the resources of all CRDs and collects the unhealthy ones. CRDs that
+// can't be listed, e.g. because of missing permissions, are recorded in Report.Failed.
+func (s *ReportService) Scan(ctx context.Context, opts ReportOptions) (*Report, error) {
+	report := &Report{
+		GeneratedAt: time.Now(),
+		Namespace:   opts.Namespace,
+		Failed:      make(map[string]string),
+	}
+
+	crds, err := s.Walk(ctx, opts, func(crd types.CRDInfo, resources []types.Resource, err error) {
+		if err != nil {
+			report.Failed[crd.Name] = err.Error()
+			return
+		}
+		report.ScannedResources += len(resources)
+		for _, res := range resources {
+			if reasons := UnhealthyReasons(res, opts.SilenceAfter, report.GeneratedAt); len(reasons) > 0 {
+				report.Resources = append(report.Resources, UnhealthyResource{CRD: crd.Name, Resource: res, Reasons: reasons})
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.ScannedCRDs = len(crds)
+
+	sort.Slice(report.Resources, func(i, j int) bool {
+		a, b := report.Resources[i], report.Resources[j]
+		if a.CRD != b.CRD {
+			return a.CRD < b.CRD
+		}
+		if a.Resource.Namespace != b.Resource.Namespace {
+			return a.Resource.Namespace < b.Resource.Namespace
+		}
+		if a.Resource.ControllerManager != b.Resource.ControllerManager {
+			return a.Resource.ControllerManager < b.Resource.ControllerManager
+		}
+		return a.Resource.Name < b.Resource.Name
+	})
+	return report, nil
+}
+
+// UnhealthyReasons returns why a resource belongs in the report: NotReady or Progressing,
+// generation drift, or no status write for longer than silenceAfter while a reconcile is
+// in flight, the same check that makes the reconcile state Stuck. Suspended resources are
+// paused on purpose and never reported.
+func UnhealthyReasons(res types.Resource, silenceAfter time.Duration, now time.Time) []string {
+	if res.Suspended {
+		return nil
+	}
+
+	var reasons []string
+	switch status := res.ReadyStatus(); status {
+	case "NotReady", "Progressing":
+		reasons = append(reasons, status)
+	}
+	if drift := res.Drift(); drift > 0 {
+		reasons = append(reasons, fmt.Sprintf("drift %d", drift))
+	}
+	// Idle resources have nothing to report, so only silence during a reconcile counts
+	if silenceAfter > 0 {
+		th := types.ReconcileThresholds{SilenceAfter: silenceAfter}
+		if state, reason := res.ClassifyReconcile(th, 0, now); state == types.ReconcileStateStuck {
+			reasons = append(reasons, "stuck: "+reason)
+		}
+	}
+	return reasons
+}
@@ -0,0 +1,137 @@
+package k8s
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pteich/crdlens/internal/config"
+	"github.com/pteich/crdlens/internal/types"
+)
+
+const reportTestManifests = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  scope: Namespaced
+  names:
+    kind: Widget
+    plural: widgets
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: healthy
+  namespace: prod
+status:
+  conditions:
+    - type: Ready
+      status: "True"
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: broken
+  namespace: prod
+status:
+  conditions:
+    - type: Ready
+      status: "False"
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: drifting
+  namespace: dev
+  generation: 3
+status:
+  observedGeneration: 2
+  conditions:
+    - type: Ready
+      status: "True"
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: paused
+  namespace: dev
+  annotations:
+    crossplane.io/paused: "true"
+status:
+  conditions:
+    - type: Ready
+      status: "False"
+`
+
+func TestReportService_Scan(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "widgets.yaml"), []byte(reportTestManifests), 0o644))
+	cfg := config.DefaultConfig()
+	cfg.FromDir = dir
+	client, err := NewClient(cfg)
+	require.NoError(t, err)
+
+	report, err := client.Report().Scan(context.Background(), ReportOptions{Concurrency: 2, PageSize: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.ScannedCRDs)
+	assert.Equal(t, 4, report.ScannedResources)
+	assert.Empty(t, report.Failed)
+
+	// Sorted by CRD and namespace, suspended resources are left out
+	require.Len(t, report.Resources, 2)
+	assert.Equal(t, "drifting", report.Resources[0].Resource.Name)
+	assert.Equal(t, []string{"drift 1"}, report.Resources[0].Reasons)
+	assert.Equal(t, "broken", report.Resources[1].Resource.Name)
+	assert.Equal(t, []string{"NotReady"}, report.Resources[1].Reasons)
+
+	groups := report.Groups()
+	require.Len(t, groups, 2)
+	assert.Equal(t, "widgets.example.com", groups[0].CRD)
+	assert.Equal(t, "dev", groups[0].Namespace)
+	assert.Equal(t, "prod", groups[1].Namespace)
+
+	report, err = client.Report().Scan(context.Background(), ReportOptions{Namespace: "prod"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.ScannedResources)
+	require.Len(t, report.Resources, 1)
+}
+
+func TestUnhealthyReasons(t *testing.T) {
+	now := time.Now()
+
+	res := types.Resource{
+		Generation:         2,
+		ObservedGeneration: 1,
+		LastStatusWrite:    now.Add(-48 * time.Hour),
+		Conditions:         []types.Condition{{Type: "Reconciling", Status: "True"}},
+	}
+	assert.Equal(t, []string{"Progressing", "drift 1", "stuck: no status update for 48h0m0s (threshold 24h0m0s)"},
+		UnhealthyReasons(res, DefaultReportSilence, now))
+	assert.Equal(t, []string{"Progressing", "drift 1"}, UnhealthyReasons(res, 0, now))
+
+	// Resources without status writes have no controller that could go silent
+	assert.Empty(t, UnhealthyReasons(types.Resource{CreatedAt: now.Add(-48 * time.Hour)}, DefaultReportSilence, now))
+
+	// Idle resources are healthy no matter how long ago their status was written
+	idle := types.Resource{
+		Generation:         2,
+		ObservedGeneration: 2,
+		LastStatusWrite:    now.Add(-30 * 24 * time.Hour),
+		Conditions:         []types.Condition{{Type: "Ready", Status: "True"}},
+	}
+	assert.Empty(t, UnhealthyReasons(idle, DefaultReportSilence, now))
+}
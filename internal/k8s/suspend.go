@@ -0,0 +1,149 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pteich/crdlens/internal/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// CrossplanePausedAnnotation pauses reconciliation of Crossplane managed resources
+const CrossplanePausedAnnotation = "crossplane.io/paused"
+
+// SuspendedSyncPolicyAnnotation keeps the automated sync policy of a suspended Argo CD
+// application so it can be restored on resume
+const SuspendedSyncPolicyAnnotation = "crdlens.io/suspended-sync-policy"
+
+// Conventions used by controllers to pause reconciliation
+const (
+	SuspendNone       = ""
+	SuspendFlux       = "flux"
+	SuspendCrossplane = "crossplane"
+	SuspendArgoCD     = "argocd"
+)
+
+// DetectSuspendConvention returns how reconciliation of obj can be paused, or SuspendNone
+func DetectSuspendConvention(obj *unstructured.Unstructured) string {
+	if obj == nil {
+		return SuspendNone
+	}
+
+	group := obj.GroupVersionKind().Group
+	switch {
+	case strings.HasSuffix(group, ".toolkit.fluxcd.io"):
+		return SuspendFlux
+	case group == "argoproj.io" && obj.GetKind() == "Application":
+		return SuspendArgoCD
+	}
+
+	// Crossplane managed resources live in provider groups, recognize them by their spec
+	if _, found := obj.GetAnnotations()[CrossplanePausedAnnotation]; found {
+		return SuspendCrossplane
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "forProvider"); found {
+		return SuspendCrossplane
+	}
+	if strings.HasSuffix(group, "crossplane.io") {
+		return SuspendCrossplane
+	}
+	return SuspendNone
+}
+
+// IsSuspended reports whether reconciliation of obj is paused by one of the known conventions
+func IsSuspended(obj *unstructured.Unstructured) bool {
+	switch DetectSuspendConvention(obj) {
+	case SuspendFlux:
+		suspended, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend")
+		return suspended
+	case SuspendCrossplane:
+		return obj.GetAnnotations()[CrossplanePausedAnnotation] == "true"
+	case SuspendArgoCD:
+		_, found := obj.GetAnnotations()[SuspendedSyncPolicyAnnotation]
+		return found
+	}
+	return false
+}
+
+// SetSuspended pauses or resumes reconciliation of res using the convention of its controller
+func (s *DynamicService) SetSuspended(ctx context.Context, res types.Resource, suspend bool) (*types.Resource, error) {
+	patch, err := suspendPatch(res.Raw, suspend)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return s.Patch(ctx, res.GVR, res.Namespace, res.Name, k8stypes.MergePatchType, data)
+}
+
+// suspendPatch builds the merge patch that suspends or resumes obj
+func suspendPatch(obj *unstructured.Unstructured, suspend bool) (map[string]interface{}, error) {
+	switch DetectSuspendConvention(obj) {
+	case SuspendFlux:
+		return map[string]interface{}{
+			"spec": map[string]interface{}{"suspend": suspend},
+		}, nil
+
+	case SuspendCrossplane:
+		var value interface{} // nil removes the annotation
+		if suspend {
+			value = "true"
+		}
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]interface{}{CrossplanePausedAnnotation: value},
+			},
+		}, nil
+
+	case SuspendArgoCD:
+		return argoCDSuspendPatch(obj, suspend)
+	}
+
+	return nil, fmt.Errorf("%s does not support suspending", obj.GetKind())
+}
+
+// argoCDSuspendPatch moves the automated sync policy into an annotation and back.
+// Argo CD has no suspend flag, disabling automated sync is the closest equivalent.
+func argoCDSuspendPatch(obj *unstructured.Unstructured, suspend bool) (map[string]interface{}, error) {
+	if suspend {
+		automated, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "syncPolicy", "automated")
+		if !found || automated == nil {
+			return nil, fmt.Errorf("application %s has no automated sync policy to suspend", obj.GetName())
+		}
+		stash, err := json.Marshal(automated)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]interface{}{SuspendedSyncPolicyAnnotation: string(stash)},
+			},
+			"spec": map[string]interface{}{
+				"syncPolicy": map[string]interface{}{"automated": nil},
+			},
+		}, nil
+	}
+
+	stash, found := obj.GetAnnotations()[SuspendedSyncPolicyAnnotation]
+	if !found {
+		return nil, fmt.Errorf("application %s was not suspended by crdlens", obj.GetName())
+	}
+	var automated map[string]interface{}
+	if err := json.Unmarshal([]byte(stash), &automated); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", SuspendedSyncPolicyAnnotation, err)
+	}
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{SuspendedSyncPolicyAnnotation: nil},
+		},
+		"spec": map[string]interface{}{
+			"syncPolicy": map[string]interface{}{"automated": automated},
+		},
+	}, nil
+}
@@ -0,0 +1,123 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/pteich/crdlens/internal/types"
+)
+
+func newTestObject(apiVersion, kind, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetNamespace("default")
+	u.SetName(name)
+	return u
+}
+
+func TestDetectSuspendConvention(t *testing.T) {
+	crossplane := newTestObject("ec2.aws.upbound.io/v1beta1", "VPC", "vpc")
+	require.NoError(t, unstructured.SetNestedField(crossplane.Object, "eu-west-1", "spec", "forProvider", "region"))
+
+	tests := []struct {
+		name     string
+		obj      *unstructured.Unstructured
+		expected string
+	}{
+		{"flux", newTestObject("kustomize.toolkit.fluxcd.io/v1", "Kustomization", "apps"), SuspendFlux},
+		{"argocd", newTestObject("argoproj.io/v1alpha1", "Application", "app"), SuspendArgoCD},
+		{"crossplane managed resource", crossplane, SuspendCrossplane},
+		{"other", newTestWidget("foo", "1"), SuspendNone},
+		{"nil", nil, SuspendNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DetectSuspendConvention(tt.obj))
+		})
+	}
+}
+
+func TestDynamicService_SetSuspended(t *testing.T) {
+	fluxGVR := schema.GroupVersionResource{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Resource: "kustomizations"}
+	argoGVR := schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "applications"}
+
+	crossplane := newTestWidget("foo", "1")
+	require.NoError(t, unstructured.SetNestedField(crossplane.Object, "eu-west-1", "spec", "forProvider", "region"))
+
+	argo := newTestObject("argoproj.io/v1alpha1", "Application", "app")
+	require.NoError(t, unstructured.SetNestedField(argo.Object, true, "spec", "syncPolicy", "automated", "prune"))
+
+	svc := NewDynamicService(newTestDynamicClient(
+		newTestObject("kustomize.toolkit.fluxcd.io/v1", "Kustomization", "apps"), crossplane, argo))
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		gvr   schema.GroupVersionResource
+		obj   string
+		check func(t *testing.T, obj *unstructured.Unstructured, suspended bool)
+	}{
+		{
+			name: "flux",
+			obj:  "apps",
+			gvr:  fluxGVR,
+			check: func(t *testing.T, obj *unstructured.Unstructured, suspended bool) {
+				suspend, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend")
+				assert.Equal(t, suspended, suspend)
+			},
+		},
+		{
+			name: "crossplane",
+			obj:  "foo",
+			gvr:  testGVR,
+			check: func(t *testing.T, obj *unstructured.Unstructured, suspended bool) {
+				_, found := obj.GetAnnotations()[CrossplanePausedAnnotation]
+				assert.Equal(t, suspended, found)
+			},
+		},
+		{
+			name: "argocd",
+			obj:  "app",
+			gvr:  argoGVR,
+			check: func(t *testing.T, obj *unstructured.Unstructured, suspended bool) {
+				_, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "syncPolicy", "automated")
+				assert.Equal(t, !suspended, found, "automated sync policy")
+				if !suspended {
+					prune, _, _ := unstructured.NestedBool(obj.Object, "spec", "syncPolicy", "automated", "prune")
+					assert.True(t, prune, "automated sync policy should be restored")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := svc.GetResource(ctx, tt.gvr, "default", tt.obj)
+			require.NoError(t, err)
+			require.False(t, res.Suspended)
+
+			res, err = svc.SetSuspended(ctx, *res, true)
+			require.NoError(t, err)
+			assert.True(t, res.Suspended)
+			tt.check(t, res.Raw, true)
+
+			res, err = svc.SetSuspended(ctx, *res, false)
+			require.NoError(t, err)
+			assert.False(t, res.Suspended)
+			tt.check(t, res.Raw, false)
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		res := types.Resource{Name: "bar", Namespace: "default", GVR: testGVR, Raw: newTestWidget("bar", "1")}
+		_, err := svc.SetSuspended(ctx, res, true)
+		assert.Error(t, err)
+	})
+}
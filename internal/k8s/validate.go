@@ -0,0 +1,254 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/listtype"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	apiservervalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"k8s.io/utils/ptr"
+)
+
+// Violation is a problem found while validating a custom resource against its CRD
+type Violation struct {
+	File    string
+	Object  string // Kind and name, e.g. Widget/default/example
+	Path    string // Field path, e.g. spec.replicas
+	Rule    string // The CEL rule or OpenAPI keyword that failed
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s: %s: %s (rule: %s)", v.File, v.Object, v.Path, v.Message, v.Rule)
+}
+
+// SchemaValidator validates custom resources offline, the same way the API server
+// does on create: defaulting, pruning of unknown fields, OpenAPI schema validation,
+// list type checks and CEL x-kubernetes-validations.
+type SchemaValidator struct {
+	crds     map[schema.GroupKind]*apiextensionsv1.CustomResourceDefinition
+	versions map[schema.GroupVersionKind]*versionValidator
+}
+
+// versionValidator holds the compiled schema of a single CRD version
+type versionValidator struct {
+	structural *structuralschema.Structural
+	openAPI    apiservervalidation.SchemaValidator
+	cel        *cel.Validator
+	rules      map[string]string // Failure message to CEL rule
+	err        error
+}
+
+// NewSchemaValidator creates a validator for resources of the given CRDs
+func NewSchemaValidator(crds []*apiextensionsv1.CustomResourceDefinition) *SchemaValidator {
+	v := &SchemaValidator{
+		crds:     make(map[schema.GroupKind]*apiextensionsv1.CustomResourceDefinition, len(crds)),
+		versions: make(map[schema.GroupVersionKind]*versionValidator),
+	}
+	for _, crd := range crds {
+		v.crds[schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}] = crd
+	}
+	return v
+}
+
+// Knows returns true if the validator has a CRD for the object's kind
+func (v *SchemaValidator) Knows(obj *unstructured.Unstructured) bool {
+	_, ok := v.crds[obj.GroupVersionKind().GroupKind()]
+	return ok
+}
+
+// Validate checks an object against the schema of its CRD version. Objects of unknown kinds
+// return an error, violations of the schema are returned as a list.
+func (v *SchemaValidator) Validate(ctx context.Context, obj *unstructured.Unstructured) ([]Violation, error) {
+	gvk := obj.GroupVersionKind()
+	crd, ok := v.crds[gvk.GroupKind()]
+	if !ok {
+		return nil, fmt.Errorf("no CRD found for %s", gvk.GroupKind())
+	}
+
+	object := objectName(obj)
+	violation := func(path, rule, message string) Violation {
+		return Violation{Object: object, Path: path, Rule: rule, Message: message}
+	}
+
+	served := false
+	for _, version := range crd.Spec.Versions {
+		if version.Name == gvk.Version && version.Served {
+			served = true
+		}
+	}
+	if !served {
+		return []Violation{violation("apiVersion", "served versions",
+			fmt.Sprintf("version %q is not served by CRD %s", gvk.Version, crd.Name))}, nil
+	}
+
+	vv := v.versionValidator(crd, gvk)
+	if vv.err != nil {
+		return nil, fmt.Errorf("invalid schema in CRD %s version %s: %w", crd.Name, gvk.Version, vv.err)
+	}
+
+	var violations []Violation
+	if obj.GetName() == "" && obj.GetGenerateName() == "" {
+		violations = append(violations, violation("metadata.name", "required", "Required value: name or generateName is required"))
+	}
+
+	// Work on a copy like the API server does: default first, then drop unknown fields
+	content := obj.DeepCopy().UnstructuredContent()
+	defaulting.Default(content, vv.structural)
+	unknown := pruning.PruneWithOptions(content, vv.structural, true, structuralschema.UnknownFieldPathOptions{
+		TrackUnknownFieldPaths: true,
+	})
+	for _, path := range unknown {
+		violations = append(violations, violation(path, "unknown field", "Unknown field, it would be dropped by the API server"))
+	}
+
+	var errs field.ErrorList
+	errs = append(errs, apiservervalidation.ValidateCustomResource(nil, content, vv.openAPI)...)
+	errs = append(errs, listtype.ValidateListSetsAndMaps(nil, vv.structural, content)...)
+	// Like the API server, CEL rules are skipped if the object doesn't have the expected shape
+	if blocked := blockingError(errs); blocked != nil {
+		errs = append(errs, blocked)
+	} else {
+		celErrs, _ := vv.cel.Validate(ctx, nil, vv.structural, content, nil, celconfig.RuntimeCELCostBudget)
+		errs = append(errs, celErrs...)
+	}
+
+	for _, err := range errs {
+		violations = append(violations, violation(fieldPath(err), vv.ruleFor(err), err.ErrorBody()))
+	}
+	return violations, nil
+}
+
+// versionValidator returns the cached validator for a CRD version, building it on first use
+func (v *SchemaValidator) versionValidator(crd *apiextensionsv1.CustomResourceDefinition, gvk schema.GroupVersionKind) *versionValidator {
+	if vv, ok := v.versions[gvk]; ok {
+		return vv
+	}
+
+	vv := &versionValidator{rules: make(map[string]string)}
+	v.versions[gvk] = vv
+
+	var props *apiextensionsv1.JSONSchemaProps
+	for _, version := range crd.Spec.Versions {
+		if version.Name == gvk.Version && version.Schema != nil {
+			props = version.Schema.OpenAPIV3Schema
+		}
+	}
+	if props == nil {
+		// Without a schema everything is accepted
+		props = &apiextensionsv1.JSONSchemaProps{Type: "object", XPreserveUnknownFields: ptr.To(true)}
+	}
+
+	internal := &apiextensions.JSONSchemaProps{}
+	if vv.err = apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(props, internal, nil); vv.err != nil {
+		return vv
+	}
+	if vv.structural, vv.err = structuralschema.NewStructural(internal); vv.err != nil {
+		return vv
+	}
+	if vv.openAPI, _, vv.err = apiservervalidation.NewSchemaValidator(internal); vv.err != nil {
+		return vv
+	}
+	vv.cel = cel.NewValidator(vv.structural, true, celconfig.PerCallLimit)
+	collectRules(props, vv.rules)
+	return vv
+}
+
+// ruleFor names the rule behind a validation error: the CEL expression if the
+// message belongs to one, otherwise the OpenAPI keyword
+func (vv *versionValidator) ruleFor(err *field.Error) string {
+	if rule, ok := vv.rules[err.Detail]; ok {
+		return rule
+	}
+	switch err.Type {
+	case field.ErrorTypeRequired:
+		return "required"
+	case field.ErrorTypeNotSupported:
+		return "enum"
+	case field.ErrorTypeTooLong:
+		return "maxLength"
+	case field.ErrorTypeTooMany:
+		return "maxItems"
+	case field.ErrorTypeTypeInvalid:
+		return "type"
+	case field.ErrorTypeDuplicate:
+		return "x-kubernetes-list-type"
+	}
+	if strings.Contains(err.Detail, "evaluating rule") || strings.Contains(err.Detail, "rule compile error") ||
+		strings.Contains(err.Detail, "validation rules were not checked") {
+		return "x-kubernetes-validations"
+	}
+	return "openapi"
+}
+
+// blockingError returns an error if errs contain errors that prevent CEL rules from being evaluated
+func blockingError(errs field.ErrorList) *field.Error {
+	for _, err := range errs {
+		switch err.Type {
+		case field.ErrorTypeNotSupported, field.ErrorTypeRequired, field.ErrorTypeTooLong,
+			field.ErrorTypeTooMany, field.ErrorTypeTypeInvalid:
+			return field.Invalid(nil, nil, "some validation rules were not checked because the object was invalid; correct the existing errors to complete validation")
+		}
+	}
+	return nil
+}
+
+// collectRules maps the failure messages of all CEL rules in a schema to the rules
+func collectRules(props *apiextensionsv1.JSONSchemaProps, rules map[string]string) {
+	if props == nil {
+		return
+	}
+	for _, v := range props.XValidations {
+		message := strings.TrimSpace(v.Message)
+		if message == "" {
+			message = "failed rule: " + strings.TrimSpace(v.Rule)
+		}
+		rules[message] = v.Rule
+	}
+	for name := range props.Properties {
+		p := props.Properties[name]
+		collectRules(&p, rules)
+	}
+	if props.Items != nil {
+		collectRules(props.Items.Schema, rules)
+	}
+	if props.AdditionalProperties != nil {
+		collectRules(props.AdditionalProperties.Schema, rules)
+	}
+	for _, alternatives := range [][]apiextensionsv1.JSONSchemaProps{props.AllOf, props.AnyOf, props.OneOf} {
+		for i := range alternatives {
+			collectRules(&alternatives[i], rules)
+		}
+	}
+}
+
+// fieldPath returns the path of a validation error, errors on the object itself get "(root)"
+func fieldPath(err *field.Error) string {
+	if err.Field == "" || err.Field == "<nil>" {
+		return "(root)"
+	}
+	return err.Field
+}
+
+// objectName formats an object as Kind/name or Kind/namespace/name
+func objectName(obj *unstructured.Unstructured) string {
+	name := obj.GetName()
+	if name == "" {
+		name = obj.GetGenerateName() + "*"
+	}
+	if ns := obj.GetNamespace(); ns != "" {
+		return fmt.Sprintf("%s/%s/%s", obj.GetKind(), ns, name)
+	}
+	return fmt.Sprintf("%s/%s", obj.GetKind(), name)
+}
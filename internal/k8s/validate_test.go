@@ -0,0 +1,94 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func validateTestCRD() *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "Widget", Plural: "widgets"},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:    "v1",
+				Served:  true,
+				Storage: true,
+				Schema: &apiextensionsv1.CustomResourceValidation{
+					OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"spec": {
+								Type:     "object",
+								Required: []string{"size"},
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"size": {Type: "integer", Minimum: ptrFloat(1)},
+									"mode": {Type: "string", Enum: []apiextensionsv1.JSON{{Raw: []byte(`"fast"`)}, {Raw: []byte(`"slow"`)}}},
+									"min":  {Type: "integer"},
+									"max":  {Type: "integer"},
+								},
+								XValidations: apiextensionsv1.ValidationRules{
+									{Rule: "!has(self.min) || !has(self.max) || self.min <= self.max", Message: "min must not exceed max"},
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func ptrFloat(f float64) *float64 {
+	return &f
+}
+
+func newWidget(spec map[string]interface{}) *unstructured.Unstructured {
+	obj := newTestObject("example.com/v1", "Widget", "example")
+	obj.SetNamespace("default")
+	obj.Object["spec"] = spec
+	return obj
+}
+
+func TestSchemaValidator_Validate(t *testing.T) {
+	v := NewSchemaValidator([]*apiextensionsv1.CustomResourceDefinition{validateTestCRD()})
+	ctx := context.Background()
+
+	violations, err := v.Validate(ctx, newWidget(map[string]interface{}{"size": int64(3), "mode": "fast"}))
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	violations, err = v.Validate(ctx, newWidget(map[string]interface{}{"size": int64(0), "mode": "medium", "color": "red"}))
+	require.NoError(t, err)
+	rules := make(map[string]string)
+	for _, violation := range violations {
+		assert.Equal(t, "Widget/default/example", violation.Object)
+		rules[violation.Path] = violation.Rule
+	}
+	assert.Equal(t, "unknown field", rules["spec.color"])
+	assert.Equal(t, "enum", rules["spec.mode"])
+	assert.Equal(t, "openapi", rules["spec.size"])
+
+	violations, err = v.Validate(ctx, newWidget(map[string]interface{}{"size": int64(1), "min": int64(5), "max": int64(2)}))
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "spec", violations[0].Path)
+	assert.Equal(t, "!has(self.min) || !has(self.max) || self.min <= self.max", violations[0].Rule)
+	assert.Contains(t, violations[0].Message, "min must not exceed max")
+
+	violations, err = v.Validate(ctx, newTestObject("example.com/v2", "Widget", "example"))
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "apiVersion", violations[0].Path)
+
+	_, err = v.Validate(ctx, newTestObject("example.com/v1", "Gadget", "example"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,235 @@
+package metrics
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/pteich/crdlens/internal/config"
+	"github.com/pteich/crdlens/internal/k8s"
+	"github.com/pteich/crdlens/internal/types"
+)
+
+// Bucket boundaries of the histograms
+var (
+	DriftBuckets   = []float64{0, 1, 2, 5, 10}
+	LagBuckets     = []float64{1, 5, 15, 30, 60, 300, 900, 3600}
+	SilenceBuckets = []float64{60, 300, 900, 3600, 6 * 3600, 24 * 3600, 7 * 24 * 3600}
+)
+
+var (
+	resourcesDesc = prometheus.NewDesc("crdlens_resources",
+		"Number of custom resources by ready status (Ready, NotReady, Progressing, Suspended, Terminating, Unknown).",
+		[]string{"crd", "namespace", "controller", "status"}, nil)
+	stuckDesc = prometheus.NewDesc("crdlens_resources_stuck",
+		"Number of custom resources whose reconcile is classified as Stuck.",
+		[]string{"crd", "namespace", "controller"}, nil)
+	driftDesc = prometheus.NewDesc("crdlens_resource_drift_generations",
+		"Difference between metadata.generation and status.observedGeneration.",
+		[]string{"crd", "namespace", "controller"}, nil)
+	lagDesc = prometheus.NewDesc("crdlens_resource_lag_seconds",
+		"Time between the last spec change and the status update that followed, or since the spec change while reconciling.",
+		[]string{"crd", "namespace", "controller"}, nil)
+	silenceDesc = prometheus.NewDesc("crdlens_resource_silence_seconds",
+		"Time since the last status update of a custom resource.",
+		[]string{"crd", "namespace", "controller"}, nil)
+	scanErrorDesc = prometheus.NewDesc("crdlens_scan_error",
+		"1 if the resources of the CRD could not be listed in the last scan.",
+		[]string{"crd"}, nil)
+	scanTimestampDesc = prometheus.NewDesc("crdlens_last_scan_timestamp_seconds",
+		"Unix time of the last completed scan.", nil, nil)
+	scanDurationDesc = prometheus.NewDesc("crdlens_last_scan_duration_seconds",
+		"Duration of the last completed scan.", nil, nil)
+)
+
+// groupKey identifies the resources of a CRD in a namespace handled by the same controller
+type groupKey struct {
+	crd        string
+	namespace  string
+	controller string
+}
+
+// histogram collects observations for a const histogram
+type histogram struct {
+	count   uint64
+	sum     float64
+	buckets map[float64]uint64
+	bounds  []float64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{buckets: make(map[float64]uint64, len(bounds)), bounds: bounds}
+}
+
+func (h *histogram) observe(v float64) {
+	h.count++
+	h.sum += v
+	for _, b := range h.bounds {
+		if v <= b {
+			h.buckets[b]++
+		}
+	}
+}
+
+// group holds the values of a groupKey collected by a scan
+type group struct {
+	status  map[string]int
+	stuck   int
+	drift   *histogram
+	lag     *histogram
+	silence *histogram
+}
+
+func newGroup() *group {
+	return &group{
+		status:  make(map[string]int),
+		drift:   newHistogram(DriftBuckets),
+		lag:     newHistogram(LagBuckets),
+		silence: newHistogram(SilenceBuckets),
+	}
+}
+
+// snapshot is the result of a scan
+type snapshot struct {
+	groups   map[groupKey]*group
+	failed   []string
+	time     time.Time
+	duration time.Duration
+}
+
+// Options configure the scans of a Collector
+type Options struct {
+	Namespace   string // Empty scans all namespaces
+	Concurrency int    // CRDs listed in parallel (0 = k8s.DefaultReportConcurrency)
+	PageSize    int64  // Resources per page (0 = k8s.DefaultPageSize)
+	Reconcile   config.ReconcileConfig
+}
+
+// Collector exposes the health of all custom resources as Prometheus metrics. Scan
+// refreshes the values, scrapes return the result of the last completed scan.
+type Collector struct {
+	client *k8s.Client
+	opts   Options
+
+	mu   sync.RWMutex
+	last *snapshot
+}
+
+// NewCollector creates a collector, nothing is exported until the first scan completed
+func NewCollector(client *k8s.Client, opts Options) *Collector {
+	return &Collector{client: client, opts: opts}
+}
+
+// Run scans every interval until ctx is done. Errors are passed to onError.
+func (c *Collector) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := c.Scan(ctx); err != nil && ctx.Err() == nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scan lists the resources of all CRDs and replaces the exported values
+func (c *Collector) Scan(ctx context.Context) error {
+	start := time.Now()
+	snap := &snapshot{groups: make(map[groupKey]*group)}
+
+	_, err := c.client.Report().Walk(ctx, k8s.ReportOptions{
+		Namespace:   c.opts.Namespace,
+		Concurrency: c.opts.Concurrency,
+		PageSize:    c.opts.PageSize,
+	}, func(crd types.CRDInfo, resources []types.Resource, err error) {
+		if err != nil {
+			snap.failed = append(snap.failed, crd.Name)
+			return
+		}
+		for _, res := range resources {
+			c.observe(snap, crd.Name, res, start)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(snap.failed)
+	snap.time = time.Now()
+	snap.duration = snap.time.Sub(start)
+
+	c.mu.Lock()
+	c.last = snap
+	c.mu.Unlock()
+	return nil
+}
+
+// observe adds a resource to its group with the same helpers the TUI uses
+func (c *Collector) observe(snap *snapshot, crd string, res types.Resource, now time.Time) {
+	key := groupKey{crd: crd, namespace: res.Namespace, controller: res.ControllerManager}
+	g, ok := snap.groups[key]
+	if !ok {
+		g = newGroup()
+		snap.groups[key] = g
+	}
+
+	g.status[res.ReadyStatus()]++
+	th := c.opts.Reconcile.ThresholdsFor(crd, res.ControllerManager)
+	if state, _ := res.ClassifyReconcile(th, 0, now); state == types.ReconcileStateStuck {
+		g.stuck++
+	}
+	g.drift.observe(float64(res.Drift()))
+	// Without a known spec write there is no lag to measure
+	if !res.SpecWriteTime.IsZero() {
+		g.lag.observe(res.Lag().Seconds())
+	}
+	// Without any status write there is no controller that could be silent
+	if !res.LastStatusWrite.IsZero() {
+		g.silence.observe(res.Silence().Seconds())
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- resourcesDesc
+	ch <- stuckDesc
+	ch <- driftDesc
+	ch <- lagDesc
+	ch <- silenceDesc
+	ch <- scanErrorDesc
+	ch <- scanTimestampDesc
+	ch <- scanDurationDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	snap := c.last
+	c.mu.RUnlock()
+	if snap == nil {
+		return
+	}
+
+	for key, g := range snap.groups {
+		labels := []string{key.crd, key.namespace, key.controller}
+		for status, n := range g.status {
+			ch <- prometheus.MustNewConstMetric(resourcesDesc, prometheus.GaugeValue, float64(n), append(labels, status)...)
+		}
+		ch <- prometheus.MustNewConstMetric(stuckDesc, prometheus.GaugeValue, float64(g.stuck), labels...)
+		ch <- prometheus.MustNewConstHistogram(driftDesc, g.drift.count, g.drift.sum, g.drift.buckets, labels...)
+		ch <- prometheus.MustNewConstHistogram(lagDesc, g.lag.count, g.lag.sum, g.lag.buckets, labels...)
+		ch <- prometheus.MustNewConstHistogram(silenceDesc, g.silence.count, g.silence.sum, g.silence.buckets, labels...)
+	}
+	for _, crd := range snap.failed {
+		ch <- prometheus.MustNewConstMetric(scanErrorDesc, prometheus.GaugeValue, 1, crd)
+	}
+	ch <- prometheus.MustNewConstMetric(scanTimestampDesc, prometheus.GaugeValue, float64(snap.time.Unix()))
+	ch <- prometheus.MustNewConstMetric(scanDurationDesc, prometheus.GaugeValue, snap.duration.Seconds())
+}
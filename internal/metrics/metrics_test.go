@@ -0,0 +1,145 @@
+package metrics
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pteich/crdlens/internal/config"
+	"github.com/pteich/crdlens/internal/k8s"
+	"github.com/pteich/crdlens/internal/types"
+)
+
+const testManifests = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  scope: Namespaced
+  names:
+    kind: Widget
+    plural: widgets
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: ready
+  namespace: prod
+status:
+  conditions:
+    - type: Ready
+      status: "True"
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: broken
+  namespace: prod
+status:
+  conditions:
+    - type: Ready
+      status: "False"
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: drifting
+  namespace: prod
+  generation: 4
+status:
+  observedGeneration: 2
+  conditions:
+    - type: Ready
+      status: "True"
+`
+
+func newTestClient(t *testing.T) *k8s.Client {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "widgets.yaml"), []byte(testManifests), 0o644))
+	cfg := config.DefaultConfig()
+	cfg.FromDir = dir
+	client, err := k8s.NewClient(cfg)
+	require.NoError(t, err)
+	return client
+}
+
+func TestCollector(t *testing.T) {
+	c := NewCollector(newTestClient(t), Options{Reconcile: config.DefaultConfig().Reconcile})
+
+	// Nothing is exported before the first scan
+	assert.Zero(t, testutil.CollectAndCount(c))
+
+	require.NoError(t, c.Scan(context.Background()))
+
+	expected := `
+# HELP crdlens_resources Number of custom resources by ready status (Ready, NotReady, Progressing, Suspended, Terminating, Unknown).
+# TYPE crdlens_resources gauge
+crdlens_resources{controller="",crd="widgets.example.com",namespace="prod",status="NotReady"} 1
+crdlens_resources{controller="",crd="widgets.example.com",namespace="prod",status="Ready"} 2
+# HELP crdlens_resources_stuck Number of custom resources whose reconcile is classified as Stuck.
+# TYPE crdlens_resources_stuck gauge
+crdlens_resources_stuck{controller="",crd="widgets.example.com",namespace="prod"} 0
+# HELP crdlens_resource_drift_generations Difference between metadata.generation and status.observedGeneration.
+# TYPE crdlens_resource_drift_generations histogram
+crdlens_resource_drift_generations_bucket{controller="",crd="widgets.example.com",namespace="prod",le="0"} 2
+crdlens_resource_drift_generations_bucket{controller="",crd="widgets.example.com",namespace="prod",le="1"} 2
+crdlens_resource_drift_generations_bucket{controller="",crd="widgets.example.com",namespace="prod",le="2"} 3
+crdlens_resource_drift_generations_bucket{controller="",crd="widgets.example.com",namespace="prod",le="5"} 3
+crdlens_resource_drift_generations_bucket{controller="",crd="widgets.example.com",namespace="prod",le="10"} 3
+crdlens_resource_drift_generations_bucket{controller="",crd="widgets.example.com",namespace="prod",le="+Inf"} 3
+crdlens_resource_drift_generations_sum{controller="",crd="widgets.example.com",namespace="prod"} 2
+crdlens_resource_drift_generations_count{controller="",crd="widgets.example.com",namespace="prod"} 3
+`
+	require.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected),
+		"crdlens_resources", "crdlens_resources_stuck", "crdlens_resource_drift_generations"))
+
+	// Resources without managed fields have neither lag nor silence, the histograms stay empty
+	assert.Equal(t, 1, testutil.CollectAndCount(c, "crdlens_resource_lag_seconds"))
+	assert.Equal(t, 1, testutil.CollectAndCount(c, "crdlens_resource_silence_seconds"))
+	assert.Equal(t, 1, testutil.CollectAndCount(c, "crdlens_last_scan_timestamp_seconds"))
+}
+
+func TestCollector_ObserveWithoutWrites(t *testing.T) {
+	c := NewCollector(nil, Options{Reconcile: config.DefaultConfig().Reconcile})
+	snap := &snapshot{groups: make(map[groupKey]*group)}
+	now := time.Now()
+
+	c.observe(snap, "widgets.example.com", types.Resource{Namespace: "prod", CreatedAt: now.Add(-time.Hour)}, now)
+	c.observe(snap, "widgets.example.com", types.Resource{
+		Namespace:       "prod",
+		SpecWriteTime:   now.Add(-time.Hour),
+		LastStatusWrite: now.Add(-time.Minute),
+	}, now)
+
+	g := snap.groups[groupKey{crd: "widgets.example.com", namespace: "prod"}]
+	require.NotNil(t, g)
+	assert.Equal(t, uint64(2), g.drift.count)
+	assert.Equal(t, uint64(1), g.lag.count, "lag needs a spec write")
+	assert.Equal(t, uint64(1), g.silence.count, "silence needs a status write")
+}
+
+func TestHistogram(t *testing.T) {
+	h := newHistogram([]float64{1, 10})
+	h.observe(0.5)
+	h.observe(5)
+	h.observe(50)
+
+	assert.Equal(t, uint64(3), h.count)
+	assert.Equal(t, 55.5, h.sum)
+	assert.Equal(t, map[float64]uint64{1: 1, 10: 2}, h.buckets)
+}
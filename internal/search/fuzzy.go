@@ -5,19 +5,18 @@ import (
 	"github.com/pteich/crdlens/internal/types"
 )
 
-// MatchResources filters a list of resources using fuzzy search on Name and Namespace
+// MatchResources filters a list of resources with a query, see Query for the syntax.
+// Plain terms fuzzy match on Name and Namespace. Invalid queries match nothing.
 func MatchResources(query string, resources []types.Resource) []types.Resource {
 	if query == "" {
 		return resources
 	}
 
-	var matched []types.Resource
-	for _, res := range resources {
-		if fuzzy.MatchFold(query, res.Name) || fuzzy.MatchFold(query, res.Namespace) {
-			matched = append(matched, res)
-		}
+	q, err := ParseQuery(query)
+	if err != nil {
+		return nil
 	}
-	return matched
+	return q.Filter(resources)
 }
 
 // MatchCRDs filters a list of CRDs using fuzzy search on Name, Kind, and Group
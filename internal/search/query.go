@@ -0,0 +1,290 @@
+package search
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/lithammer/fuzzysearch/fuzzy"
+	"github.com/pteich/crdlens/internal/k8s"
+	"github.com/pteich/crdlens/internal/types"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Query is a parsed CR list filter. All terms must match (AND), terms can be negated with NOT.
+//
+// Supported terms:
+//
+//	nginx                 fuzzy match on name and namespace
+//	status:NotReady       ready status (Ready, NotReady, Progressing, Suspended, Terminating, Unknown)
+//	ctrl:flux             controller family or managing controller
+//	drift>0               generation drift, also with <, >=, <=, =
+//	age<1h                time since creation, units s, m, h and d
+//	label:app=foo         label selector, e.g. label:"tier in (web,db)"
+//	cond:Ready=False      condition status, or cond:Ready for any status
+//	jsonpath:.spec.foo=bar  value at a JSONPath, or jsonpath:.spec.foo if it is set
+type Query struct {
+	terms []queryTerm
+}
+
+type queryTerm struct {
+	negate bool
+	match  func(res types.Resource, now time.Time) bool
+}
+
+// ParseQuery parses a filter query, an empty query matches everything
+func ParseQuery(query string) (*Query, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{}
+	negate := false
+	for _, token := range tokens {
+		switch {
+		case token == "AND":
+			continue
+		case token == "NOT":
+			negate = !negate
+			continue
+		}
+
+		if strings.HasPrefix(token, "!") && len(token) > 1 {
+			negate = !negate
+			token = token[1:]
+		}
+
+		match, err := parseTerm(token)
+		if err != nil {
+			return nil, err
+		}
+		q.terms = append(q.terms, queryTerm{negate: negate, match: match})
+		negate = false
+	}
+	if negate {
+		return nil, fmt.Errorf("NOT must be followed by a term")
+	}
+	return q, nil
+}
+
+// Match returns true if the resource matches all terms
+func (q *Query) Match(res types.Resource, now time.Time) bool {
+	for _, t := range q.terms {
+		if t.match(res, now) == t.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the resources that match the query
+func (q *Query) Filter(resources []types.Resource) []types.Resource {
+	if len(q.terms) == 0 {
+		return resources
+	}
+
+	now := time.Now()
+	var matched []types.Resource
+	for _, res := range resources {
+		if q.Match(res, now) {
+			matched = append(matched, res)
+		}
+	}
+	return matched
+}
+
+// tokenize splits a query at whitespace, double quotes group text with spaces
+func tokenize(query string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case unicode.IsSpace(r) && !inQuotes:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("missing closing quote")
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// parseTerm turns a single token into a matcher
+func parseTerm(token string) (func(types.Resource, time.Time) bool, error) {
+	for _, name := range []string{"drift", "age"} {
+		if rest, ok := strings.CutPrefix(token, name); ok && rest != "" && strings.ContainsAny(rest[:1], "<>=:") {
+			return parseComparison(name, rest)
+		}
+	}
+
+	qualifier, value, found := strings.Cut(token, ":")
+	if !found {
+		return func(res types.Resource, _ time.Time) bool {
+			return fuzzy.MatchFold(token, res.Name) || fuzzy.MatchFold(token, res.Namespace)
+		}, nil
+	}
+	if value == "" {
+		return nil, fmt.Errorf("%s: missing value", qualifier)
+	}
+
+	switch qualifier {
+	case "status":
+		return func(res types.Resource, _ time.Time) bool {
+			return strings.EqualFold(res.ReadyStatus(), value)
+		}, nil
+
+	case "ctrl":
+		value = strings.ToLower(value)
+		return func(res types.Resource, _ time.Time) bool {
+			return k8s.DetectControllerFamily(res) == value ||
+				strings.Contains(strings.ToLower(k8s.ShortenManagerName(res.ControllerManager)), value) ||
+				strings.Contains(strings.ToLower(res.ControllerManager), value)
+		}, nil
+
+	case "label":
+		selector, err := labels.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("label: %w", err)
+		}
+		return func(res types.Resource, _ time.Time) bool {
+			if res.Raw == nil {
+				return selector.Empty()
+			}
+			return selector.Matches(labels.Set(res.Raw.GetLabels()))
+		}, nil
+
+	case "cond":
+		condType, status, hasStatus := strings.Cut(value, "=")
+		return func(res types.Resource, _ time.Time) bool {
+			for _, c := range res.Conditions {
+				if strings.EqualFold(c.Type, condType) && (!hasStatus || strings.EqualFold(c.Status, status)) {
+					return true
+				}
+			}
+			return false
+		}, nil
+
+	case "jsonpath":
+		return parseJSONPathTerm(value)
+	}
+
+	return nil, fmt.Errorf("unknown qualifier %q, use status, ctrl, drift, age, label, cond or jsonpath", qualifier)
+}
+
+// parseComparison parses drift and age comparisons like drift>0 or age<=2d
+func parseComparison(name, rest string) (func(types.Resource, time.Time) bool, error) {
+	op := rest[:1]
+	if strings.HasPrefix(rest, "<=") || strings.HasPrefix(rest, ">=") {
+		op = rest[:2]
+	}
+	value := strings.TrimPrefix(rest, op)
+	if op == ":" {
+		op = "="
+	}
+	if value == "" {
+		return nil, fmt.Errorf("%s%s: missing value", name, op)
+	}
+
+	compare := func(a, b int64) bool {
+		switch op {
+		case "<":
+			return a < b
+		case "<=":
+			return a <= b
+		case ">":
+			return a > b
+		case ">=":
+			return a >= b
+		default:
+			return a == b
+		}
+	}
+
+	if name == "drift" {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("drift: %q is not a number", value)
+		}
+		return func(res types.Resource, _ time.Time) bool {
+			return compare(res.Drift(), n)
+		}, nil
+	}
+
+	d, err := parseAge(value)
+	if err != nil {
+		return nil, err
+	}
+	return func(res types.Resource, now time.Time) bool {
+		if res.CreatedAt.IsZero() {
+			return false
+		}
+		return compare(int64(now.Sub(res.CreatedAt)), int64(d))
+	}, nil
+}
+
+// parseAge parses durations like 90s, 15m, 1h30m or 7d
+func parseAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err == nil {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("age: %q is not a duration like 30m, 2h or 7d", value)
+	}
+	return d, nil
+}
+
+// parseJSONPathTerm parses jsonpath:<path>=<value> or jsonpath:<path>
+func parseJSONPathTerm(value string) (func(types.Resource, time.Time) bool, error) {
+	path, expected, hasValue := splitJSONPathValue(value)
+
+	if err := jsonpath.New("query").Parse(fmt.Sprintf("{%s}", path)); err != nil {
+		return nil, fmt.Errorf("jsonpath: %w", err)
+	}
+
+	col := types.PrinterColumn{Name: "query", Type: "string", JSONPath: path}
+	return func(res types.Resource, now time.Time) bool {
+		actual := k8s.PrinterColumnValue(res.Raw, col, now)
+		if !hasValue {
+			return actual != ""
+		}
+		return actual == expected
+	}, nil
+}
+
+// splitJSONPathValue splits at the first = that is not part of a filter expression
+func splitJSONPathValue(value string) (path, expected string, found bool) {
+	depth := 0
+	for i, r := range value {
+		switch r {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		case '=':
+			if depth == 0 {
+				return value[:i], value[i+1:], true
+			}
+		}
+	}
+	return value, "", false
+}
@@ -0,0 +1,96 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pteich/crdlens/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func queryTestResources(now time.Time) []types.Resource {
+	newRaw := func(labels map[string]string, spec map[string]interface{}) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+		u.SetLabels(labels)
+		return u
+	}
+	return []types.Resource{
+		{
+			Name: "web-frontend", Namespace: "prod", CreatedAt: now.Add(-30 * time.Minute),
+			Generation: 3, ObservedGeneration: 2, ControllerManager: "helm-controller",
+			Conditions: []types.Condition{{Type: "Ready", Status: "False"}},
+			Raw:        newRaw(map[string]string{"app": "web"}, map[string]interface{}{"tier": "frontend"}),
+		},
+		{
+			Name: "db-primary", Namespace: "prod", CreatedAt: now.Add(-48 * time.Hour),
+			Generation: 1, ObservedGeneration: 1, ControllerManager: "crossplane",
+			Conditions: []types.Condition{{Type: "Ready", Status: "True"}},
+			Raw:        newRaw(map[string]string{"app": "db"}, map[string]interface{}{"tier": "backend"}),
+		},
+		{
+			Name: "web-staging", Namespace: "staging", CreatedAt: now.Add(-3 * time.Hour),
+			Raw: newRaw(map[string]string{"app": "web"}, map[string]interface{}{}),
+		},
+	}
+}
+
+func TestQuery_Match(t *testing.T) {
+	now := time.Now()
+	resources := queryTestResources(now)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"web-frontend", "db-primary", "web-staging"}},
+		{"web", []string{"web-frontend", "web-staging"}},
+		{"status:NotReady", []string{"web-frontend"}},
+		{"status:ready", []string{"db-primary"}},
+		{"ctrl:flux", []string{"web-frontend"}},
+		{"ctrl:crossplane", []string{"db-primary"}},
+		{"drift>0", []string{"web-frontend"}},
+		{"drift:0", []string{"db-primary", "web-staging"}},
+		{"age<1h", []string{"web-frontend"}},
+		{"age>=1d", []string{"db-primary"}},
+		{"label:app=web", []string{"web-frontend", "web-staging"}},
+		{`label:"app in (db)"`, []string{"db-primary"}},
+		{"cond:Ready=False", []string{"web-frontend"}},
+		{"cond:Ready", []string{"web-frontend", "db-primary"}},
+		{"jsonpath:.spec.tier=backend", []string{"db-primary"}},
+		{"jsonpath:.spec.tier", []string{"web-frontend", "db-primary"}},
+		{"web AND NOT status:NotReady", []string{"web-staging"}},
+		{"label:app=web !prod", []string{"web-staging"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			var names []string
+			for _, res := range resources {
+				if q.Match(res, now) {
+					names = append(names, res.Name)
+				}
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	for _, query := range []string{
+		"status:",
+		"drift>x",
+		"age<soon",
+		"label:!!app",
+		"jsonpath:.spec[",
+		"owner:me",
+		`label:"app in (web)`,
+		"web NOT",
+	} {
+		_, err := ParseQuery(query)
+		assert.Error(t, err, query)
+	}
+}
@@ -4,11 +4,61 @@ import "k8s.io/apimachinery/pkg/runtime/schema"
 
 // CRDInfo contains metadata about a discovered CRD
 type CRDInfo struct {
-	Name    string
-	Group   string
-	Version string
-	Kind    string
-	Scope   string // Namespaced or Cluster
-	GVR     schema.GroupVersionResource
-	Count   int // Number of instances (optional/cached)
+	Name       string
+	Group      string
+	Version    string // Version used to browse resources
+	Kind       string
+	Scope      string // Namespaced or Cluster
+	GVR        schema.GroupVersionResource
+	Count      int          // Number of instances (optional/cached)
+	Versions   []CRDVersion // All versions defined by the CRD
+	Conversion string       // Conversion strategy between versions (None or Webhook)
+}
+
+// CRDVersion describes a single version of a CRD
+type CRDVersion struct {
+	Name               string
+	Served             bool
+	Storage            bool
+	Deprecated         bool
+	DeprecationWarning string
+	PrinterColumns     []PrinterColumn // additionalPrinterColumns of this version
+}
+
+// PrinterColumn is an additional column shown by kubectl get
+type PrinterColumn struct {
+	Name        string
+	Type        string // integer, number, string, boolean or date
+	Format      string
+	Description string
+	Priority    int32 // Columns with a priority > 0 are only shown in wide output
+	JSONPath    string
+}
+
+// ServedVersions returns the versions that can be used to browse resources
+func (c CRDInfo) ServedVersions() []CRDVersion {
+	var served []CRDVersion
+	for _, v := range c.Versions {
+		if v.Served {
+			served = append(served, v)
+		}
+	}
+	return served
+}
+
+// CurrentVersion returns the details of the selected version
+func (c CRDInfo) CurrentVersion() CRDVersion {
+	for _, v := range c.Versions {
+		if v.Name == c.Version {
+			return v
+		}
+	}
+	return CRDVersion{Name: c.Version, Served: true}
+}
+
+// WithVersion returns a copy of the CRD that browses resources through the given version
+func (c CRDInfo) WithVersion(version string) CRDInfo {
+	c.Version = version
+	c.GVR.Version = version
+	return c
 }
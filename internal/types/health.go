@@ -0,0 +1,43 @@
+package types
+
+// HealthRule defines how the ready status of the resources of a GroupKind is derived.
+// All parts that are set are evaluated and combined: Progressing wins over NotReady,
+// Ready is only returned if nothing indicates NotReady.
+type HealthRule struct {
+	// ReadyConditions mean Ready if True and NotReady if False, e.g. Ready
+	ReadyConditions []string `yaml:"readyConditions,omitempty"`
+	// NotReadyConditions mean NotReady if True, e.g. Stalled
+	NotReadyConditions []string `yaml:"notReadyConditions,omitempty"`
+	// ProgressingConditions mean Progressing if True, e.g. Reconciling
+	ProgressingConditions []string `yaml:"progressingConditions,omitempty"`
+
+	// Field is a JSONPath like .status.phase whose value is looked up in the lists below
+	Field       string   `yaml:"field,omitempty"`
+	Ready       []string `yaml:"ready,omitempty"`
+	NotReady    []string `yaml:"notReady,omitempty"`
+	Progressing []string `yaml:"progressing,omitempty"`
+
+	// Expressions are CEL expressions evaluated against the object as self
+	Expressions HealthExpressions `yaml:"expressions,omitempty"`
+}
+
+// HealthExpressions are CEL expressions that indicate a ready status if they evaluate to true
+type HealthExpressions struct {
+	Ready       string `yaml:"ready,omitempty"`
+	NotReady    string `yaml:"notReady,omitempty"`
+	Progressing string `yaml:"progressing,omitempty"`
+}
+
+// ResolveReadyStatus combines the indications of conditions and status fields to a ready status
+func ResolveReadyStatus(ready, notReady, progressing bool) string {
+	if progressing {
+		return "Progressing"
+	}
+	if ready && !notReady {
+		return "Ready"
+	}
+	if notReady {
+		return "NotReady"
+	}
+	return "Unknown"
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReconcileThresholds configure when a reconcile is classified as Stuck or Error
+type ReconcileThresholds struct {
+	StuckAfter    time.Duration `yaml:"stuckAfter"`    // In flight for longer than this is Stuck
+	SilenceAfter  time.Duration `yaml:"silenceAfter"`  // No status write for longer than this while in flight is Stuck
+	WarningEvents int           `yaml:"warningEvents"` // Recent Warning events that make the state Error, 0 disables the check
+}
+
+// ClassifyReconcile combines drift, lag, silence, conditions and the number of recent
+// Warning events into a reconcile state. The reason explains the state for humans.
+//
+//   - Error: a Stalled condition, too many Warning events, or NotReady without pending work
+//   - Stuck: in flight for longer than StuckAfter, or without status write for SilenceAfter
+//   - InFlight: drift or a Reconciling/Progressing condition
+//   - Idle: everything else, including suspended resources
+func (r Resource) ClassifyReconcile(th ReconcileThresholds, recentWarnings int, now time.Time) (ReconcileState, string) {
+	if r.Suspended {
+		return ReconcileStateIdle, "reconciliation is suspended"
+	}
+
+	for _, c := range r.Conditions {
+		if c.Type == "Stalled" && c.Status == "True" {
+			reason := "Stalled condition is True"
+			if c.Reason != "" {
+				reason += ": " + c.Reason
+			}
+			return ReconcileStateError, reason
+		}
+	}
+
+	if th.WarningEvents > 0 && recentWarnings >= th.WarningEvents {
+		return ReconcileStateError, fmt.Sprintf("%d recent Warning events", recentWarnings)
+	}
+
+	if since, inFlight := r.inFlightSince(); inFlight {
+		if th.StuckAfter > 0 && !since.IsZero() && now.Sub(since) > th.StuckAfter {
+			return ReconcileStateStuck, fmt.Sprintf("in flight for %v (threshold %v)",
+				now.Sub(since).Round(time.Second), th.StuckAfter)
+		}
+
+		// Silence counts from the start of the reconcile, older status writes don't matter
+		lastActivity := r.LastStatusWrite
+		if since.After(lastActivity) {
+			lastActivity = since
+		}
+		if th.SilenceAfter > 0 && !lastActivity.IsZero() && now.Sub(lastActivity) > th.SilenceAfter {
+			return ReconcileStateStuck, fmt.Sprintf("no status update for %v (threshold %v)",
+				now.Sub(lastActivity).Round(time.Second), th.SilenceAfter)
+		}
+		return ReconcileStateInFlight, "controller is processing changes"
+	}
+
+	if r.ReadyStatus() == "NotReady" {
+		return ReconcileStateError, "controller reports NotReady"
+	}
+	return ReconcileStateIdle, "controller has processed the latest spec"
+}
+
+// inFlightSince returns whether a reconcile is running and when it started: with the
+// spec write that caused the drift or the transition of a Reconciling/Progressing condition.
+// The time is zero if it is not known.
+func (r Resource) inFlightSince() (time.Time, bool) {
+	if r.IsReconciling() {
+		return r.SpecWriteTime, true
+	}
+	for _, c := range r.Conditions {
+		if (c.Type == "Reconciling" || c.Type == "Progressing") && c.Status == "True" {
+			return c.LastTransitionTime, true
+		}
+	}
+	return time.Time{}, false
+}
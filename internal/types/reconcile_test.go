@@ -0,0 +1,95 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResource_ClassifyReconcile(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	th := ReconcileThresholds{StuckAfter: 15 * time.Minute, SilenceAfter: 5 * time.Minute, WarningEvents: 3}
+
+	tests := []struct {
+		name     string
+		res      Resource
+		warnings int
+		expected ReconcileState
+	}{
+		{
+			name:     "idle without drift",
+			res:      Resource{Generation: 2, ObservedGeneration: 2},
+			expected: ReconcileStateIdle,
+		},
+		{
+			name: "in flight with drift and recent status write",
+			res: Resource{Generation: 3, ObservedGeneration: 2,
+				SpecWriteTime: now.Add(-2 * time.Minute), LastStatusWrite: now.Add(-time.Minute)},
+			expected: ReconcileStateInFlight,
+		},
+		{
+			name: "old status writes don't count as silence",
+			res: Resource{Generation: 3, ObservedGeneration: 2,
+				SpecWriteTime: now.Add(-2 * time.Minute), LastStatusWrite: now.Add(-time.Hour)},
+			expected: ReconcileStateInFlight,
+		},
+		{
+			name: "stuck after threshold",
+			res: Resource{Generation: 3, ObservedGeneration: 2,
+				SpecWriteTime: now.Add(-20 * time.Minute), LastStatusWrite: now.Add(-time.Minute)},
+			expected: ReconcileStateStuck,
+		},
+		{
+			name: "stuck by silence",
+			res: Resource{Generation: 3, ObservedGeneration: 2,
+				SpecWriteTime: now.Add(-10 * time.Minute), LastStatusWrite: now.Add(-8 * time.Minute)},
+			expected: ReconcileStateStuck,
+		},
+		{
+			name: "stuck progressing condition",
+			res: Resource{Conditions: []Condition{
+				{Type: "Reconciling", Status: "True", LastTransitionTime: now.Add(-time.Hour)},
+			}, LastStatusWrite: now.Add(-time.Minute)},
+			expected: ReconcileStateStuck,
+		},
+		{
+			name:     "stalled condition",
+			res:      Resource{Conditions: []Condition{{Type: "Stalled", Status: "True"}}},
+			expected: ReconcileStateError,
+		},
+		{
+			name:     "warning events",
+			res:      Resource{Generation: 2, ObservedGeneration: 2},
+			warnings: 3,
+			expected: ReconcileStateError,
+		},
+		{
+			name:     "not ready without pending work",
+			res:      Resource{Conditions: []Condition{{Type: "Ready", Status: "False"}}},
+			expected: ReconcileStateError,
+		},
+		{
+			name: "suspended",
+			res: Resource{Suspended: true, Conditions: []Condition{{Type: "Stalled", Status: "True"}},
+				Generation: 3, ObservedGeneration: 2, SpecWriteTime: now.Add(-time.Hour)},
+			expected: ReconcileStateIdle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, reason := tt.res.ClassifyReconcile(th, tt.warnings, now)
+			assert.Equal(t, tt.expected, state, reason)
+			assert.NotEmpty(t, reason)
+		})
+	}
+}
+
+func TestResource_ClassifyReconcile_DisabledThresholds(t *testing.T) {
+	now := time.Now()
+	res := Resource{Generation: 3, ObservedGeneration: 2, SpecWriteTime: now.Add(-24 * time.Hour)}
+
+	state, _ := res.ClassifyReconcile(ReconcileThresholds{}, 10, now)
+	assert.Equal(t, ReconcileStateInFlight, state)
+}
@@ -59,6 +59,22 @@ type Resource struct {
 	CreatedAt time.Time
 	Raw       *unstructured.Unstructured
 
+	// Deletion state
+	DeletionTimestamp time.Time // metadata.deletionTimestamp (zero if not being deleted)
+	Finalizers        []string  // metadata.finalizers
+
+	// Suspended is set if reconciliation was paused (Flux suspend, Crossplane paused, ...)
+	Suspended bool
+
+	// Health is the ready status derived from the HealthRule of the GroupKind,
+	// empty if there is no rule and the built-in checks apply
+	Health string
+
+	// KStatus is the status computed by kstatus (InProgress, Current, Failed, Terminating,
+	// NotFound or Unknown) with its message, shown alongside the ready status
+	KStatus        string
+	KStatusMessage string
+
 	// Controller-Aware Fields
 	Generation         int64       // metadata.generation
 	ObservedGeneration int64       // status.observedGeneration (0 if not present)
@@ -116,7 +132,24 @@ func (r Resource) HasObservedGeneration() bool {
 	return r.ObservedGeneration > 0
 }
 
+// IsTerminating returns true if the resource has been deleted and waits for its finalizers
+func (r Resource) IsTerminating() bool {
+	return !r.DeletionTimestamp.IsZero()
+}
+
+// ReadyStatus returns Terminating, Suspended, Ready, NotReady, Progressing or Unknown.
+// Resources without a health rule are checked for common conditions and status fields.
 func (r Resource) ReadyStatus() string {
+	if r.IsTerminating() {
+		return "Terminating"
+	}
+	if r.Suspended {
+		return "Suspended"
+	}
+	if r.Health != "" {
+		return r.Health
+	}
+
 	var ready, notReady, progressing bool
 
 	if len(r.Conditions) > 0 {
@@ -161,20 +194,18 @@ func (r Resource) ReadyStatus() string {
 		}
 	}
 
-	if progressing {
-		return "Progressing"
-	}
-	if ready && !notReady {
-		return "Ready"
-	}
-	if notReady {
-		return "NotReady"
-	}
-	return "Unknown"
+	return ResolveReadyStatus(ready, notReady, progressing)
 }
 
 // ReadyIcon returns an icon representing the ready status
 func (r Resource) ReadyIcon() string {
+	if r.IsTerminating() {
+		return "🗑"
+	}
+	if r.Suspended {
+		return "⏸"
+	}
+
 	// Only show reconciling spinner if we actually have observedGeneration
 	if r.HasObservedGeneration() && r.IsReconciling() {
 		return "⏳" // Reconciling/syncing
@@ -2,6 +2,7 @@ package types
 
 import (
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -225,10 +226,8 @@ func TestResource_ReadyIcon(t *testing.T) {
 		conditions []interface{}
 		expected   string
 	}{
-		// Note: kstatus doesn't strictly follow the custom HasObservedGeneration && IsReconciling logic
-		// exactly the same way (it computes a holistic status).
-		// However, missing ObservedGeneration might yield a different status if spec.generation matches (or not).
-		// For simplicity, we test the output of ReadyIcon which now delegates to kstatus.
+		// ReadyIcon uses the built-in checks here. kstatus is an optional engine computed
+		// in the k8s package and only reaches ReadyStatus through Resource.Health.
 
 		{
 			name:       "ready shows checkmark",
@@ -296,6 +295,58 @@ func TestResource_ReadyIcon(t *testing.T) {
 	}
 }
 
+func TestResource_Terminating(t *testing.T) {
+	res := Resource{
+		Conditions:        []Condition{{Type: "Ready", Status: "True"}},
+		DeletionTimestamp: time.Now(),
+		Finalizers:        []string{"example.com/cleanup"},
+	}
+
+	if !res.IsTerminating() {
+		t.Error("IsTerminating() = false, want true")
+	}
+	if status := res.ReadyStatus(); status != "Terminating" {
+		t.Errorf("ReadyStatus() = %s, want Terminating", status)
+	}
+	if icon := res.ReadyIcon(); icon != "🗑" {
+		t.Errorf("ReadyIcon() = %s, want 🗑", icon)
+	}
+}
+
+func TestResource_Suspended(t *testing.T) {
+	res := Resource{
+		Conditions: []Condition{{Type: "Ready", Status: "True"}},
+		Suspended:  true,
+	}
+
+	if status := res.ReadyStatus(); status != "Suspended" {
+		t.Errorf("ReadyStatus() = %s, want Suspended", status)
+	}
+	if icon := res.ReadyIcon(); icon != "⏸" {
+		t.Errorf("ReadyIcon() = %s, want ⏸", icon)
+	}
+}
+
+func TestResource_Health(t *testing.T) {
+	// A health rule result wins over the built-in condition checks
+	res := Resource{
+		Conditions: []Condition{{Type: "Ready", Status: "True"}},
+		Health:     "NotReady",
+	}
+
+	if status := res.ReadyStatus(); status != "NotReady" {
+		t.Errorf("ReadyStatus() = %s, want NotReady", status)
+	}
+	if icon := res.ReadyIcon(); icon != "❌" {
+		t.Errorf("ReadyIcon() = %s, want ❌", icon)
+	}
+
+	res.Suspended = true
+	if status := res.ReadyStatus(); status != "Suspended" {
+		t.Errorf("ReadyStatus() = %s, want Suspended", status)
+	}
+}
+
 func TestCondition_IsReady(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -22,14 +22,32 @@ type Model struct {
 	err       error
 	ready     bool
 
-	crdList  *views.CRDListModel
-	crList   *views.CRListModel
-	crDetail *views.CRDetailModel
-	crdSpec  *views.CRDSpecModel
-	nsPicker *views.NSPickerModel
-	help     *views.HelpModel
-	showHelp bool
-	spinner  spinner.Model
+	crdList   *views.CRDListModel
+	crList    *views.CRListModel
+	crDetail  *views.CRDetailModel
+	crdSpec   *views.CRDSpecModel
+	nsPicker  *views.NSPickerModel
+	ctxPicker *views.ContextPickerModel
+	report    *views.ReportModel
+	help      *views.HelpModel
+	showHelp  bool
+	spinner   spinner.Model
+
+	// detailStack holds the detail views left by opening a resource from the owner tree
+	detailStack []*views.CRDetailModel
+	// detailFromReport is set if the detail view was opened from the report dashboard
+	detailFromReport bool
+
+	// newClient creates the clients when switching to another kubeconfig context
+	newClient func(cfg *config.Config) (*k8s.Client, error)
+	// contextNamespaces remembers the namespace last used in each context
+	contextNamespaces map[string]namespaceChoice
+}
+
+// namespaceChoice is the namespace selection of a context
+type namespaceChoice struct {
+	namespace string
+	all       bool
 }
 
 // NewModel creates a new root model
@@ -39,11 +57,13 @@ func NewModel(cfg *config.Config, client *k8s.Client) Model {
 	s.Style = SpinnerStyle
 
 	return Model{
-		state:   CRDListView,
-		config:  cfg,
-		client:  client,
-		help:    views.NewHelpModel(),
-		spinner: s,
+		state:             CRDListView,
+		config:            cfg,
+		client:            client,
+		help:              views.NewHelpModel(),
+		spinner:           s,
+		newClient:         k8s.NewClient,
+		contextNamespaces: make(map[string]namespaceChoice),
 	}
 }
 
@@ -61,11 +81,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	cmds = append(cmds, spinnerCmd)
 
 	// Calculate filtering state for all models
+	// Open dialogs capture key presses just like filtering does
 	isFiltering := false
 	if m.crdList != nil && m.crdList.IsFiltering() {
 		isFiltering = true
 	} else if m.crList != nil && m.crList.IsFiltering() {
 		isFiltering = true
+	} else if m.state == CRDListView && m.crdList != nil && m.crdList.HasActiveDialog() {
+		isFiltering = true
+	} else if m.state == CRListView && m.crList != nil && m.crList.HasActiveDialog() {
+		isFiltering = true
+	} else if m.state == CRDetailView && m.crDetail != nil && m.crDetail.HasActiveDialog() {
+		isFiltering = true
+	} else if m.state == CRDSpecView && m.crdSpec != nil && m.crdSpec.HasActiveDialog() {
+		isFiltering = true
 	}
 
 	switch msg := msg.(type) {
@@ -85,8 +114,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.crdList != nil {
 			cmds = append(cmds, m.crdList.Refresh(msg.Namespace))
 		}
+		if m.report != nil {
+			cmds = append(cmds, m.report.Refresh(m.client.Namespace))
+		}
 		return m, tea.Batch(cmds...)
 
+	case views.ContextSelectedMsg:
+		if msg.Context == m.client.Context {
+			m.state = m.prevState
+			return m, nil
+		}
+		return m.switchContext(msg.Context)
+
+	case views.OpenResourceMsg:
+		if m.crDetail != nil {
+			m.detailStack = append(m.detailStack, m.crDetail)
+		}
+		m.crDetail = views.NewCRDetailModel(m.client, m.config, msg.Resource, m.width, m.height)
+		return m, tea.Batch(m.crDetail.Init(), m.crDetail.ShowTree())
+
 	case views.SwitchToAllNamespacesMsg:
 		m.config.AllNamespaces = true
 		m.client.Namespace = ""
@@ -96,10 +142,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.crdList != nil {
 			cmds = append(cmds, m.crdList.Refresh("all-namespaces"))
 		}
+		if m.report != nil {
+			cmds = append(cmds, m.report.Refresh(""))
+		}
 		return m, tea.Batch(cmds...)
 
 	case tea.KeyMsg:
-		if !isFiltering && m.state != NSPickerView {
+		if !isFiltering && m.state != NSPickerView && m.state != ContextPickerView {
 			switch msg.String() {
 			case "q", "ctrl+c":
 				return m, tea.Quit
@@ -111,6 +160,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.state = NSPickerView
 				m.nsPicker = views.NewNSPickerModel(m.client, m.width, m.height)
 				return m, m.nsPicker.Init()
+			case "c":
+				if m.state == CRDListView || m.state == CRListView {
+					m.prevState = m.state
+					m.state = ContextPickerView
+					m.ctxPicker = views.NewContextPickerModel(m.config, m.client.Context, m.width, m.height)
+					return m, m.ctxPicker.Init()
+				}
 			case "r":
 				switch m.state {
 				case CRDListView:
@@ -129,6 +185,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 						return m, m.crList.Refresh(ns)
 					}
+				case ReportView:
+					if m.report != nil {
+						ns := m.client.Namespace
+						if m.config.AllNamespaces {
+							ns = ""
+						}
+						return m, m.report.Refresh(ns)
+					}
 				}
 			case "enter":
 				switch m.state {
@@ -141,7 +205,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							if m.config.AllNamespaces {
 								ns = ""
 							}
+							if m.crList != nil {
+								m.crList.Close()
+							}
 							m.crList = views.NewCRListModel(m.client, selected, ns, m.width, m.height)
+							m.crList.SetReconcileConfig(m.config.Reconcile)
+							m.crList.SetFavorites(m.config)
 							return m, m.crList.Init()
 						}
 					}
@@ -150,7 +219,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						selected := m.crList.SelectedResource()
 						if selected.Name != "" {
 							m.state = CRDetailView
-							m.crDetail = views.NewCRDetailModel(m.client, selected, m.width, m.height)
+							m.detailStack = nil
+							m.detailFromReport = false
+							m.crDetail = views.NewCRDetailModel(m.client, m.config, selected, m.width, m.height)
+							return m, m.crDetail.Init()
+						}
+					}
+				case ReportView:
+					if m.report != nil {
+						selected := m.report.SelectedResource()
+						if selected.Name != "" {
+							m.state = CRDetailView
+							m.detailStack = nil
+							m.detailFromReport = true
+							m.crDetail = views.NewCRDetailModel(m.client, m.config, selected, m.width, m.height)
 							return m, m.crDetail.Init()
 						}
 					}
@@ -167,10 +249,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 					}
 				}
+			case "u":
+				if m.state == CRDListView {
+					ns := m.client.Namespace
+					if m.config.AllNamespaces {
+						ns = ""
+					}
+					m.state = ReportView
+					m.report = views.NewReportModel(m.client, ns, m.width, m.height)
+					return m, m.report.Init()
+				}
 			case "esc":
 				switch m.state {
 				case CRListView:
 					m.state = CRDListView
+					if m.crList != nil {
+						m.crList.Close()
+					}
 					return m, nil
 				case CRDetailView:
 					// Check if we have navigation history in the detail view
@@ -179,8 +274,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.crDetail = newModel.(*views.CRDetailModel)
 						return m, cmd
 					}
+					// Go back to the resource the owner tree was opened from
+					if n := len(m.detailStack); n > 0 {
+						m.crDetail = m.detailStack[n-1]
+						m.detailStack = m.detailStack[:n-1]
+						return m, nil
+					}
+					if m.detailFromReport {
+						m.state = ReportView
+						return m, nil
+					}
 					m.state = CRListView
 					return m, nil
+				case ReportView:
+					m.state = CRDListView
+					return m, nil
 				case CRDSpecView:
 					// Check if we are showing field details or have navigation history
 					if m.crdSpec != nil && (m.crdSpec.IsShowingFieldDetail() || m.crdSpec.HasNavigationHistory()) {
@@ -196,6 +304,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else if m.state == NSPickerView && msg.String() == "esc" {
 			m.state = m.prevState
 			return m, nil
+		} else if m.state == ContextPickerView && msg.String() == "esc" && !m.ctxPicker.IsFiltering() {
+			m.state = m.prevState
+			return m, nil
 		}
 
 	case tea.WindowSizeMsg:
@@ -208,6 +319,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				ns = "all-namespaces"
 			}
 			m.crdList = views.NewCRDListModel(m.client, ns, m.width, m.height, m.config.DisableCounts)
+			m.crdList.SetFavorites(m.config)
 			cmds = append(cmds, m.crdList.Init())
 		} else {
 			if m.crdList != nil {
@@ -255,12 +367,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.crdSpec = newModel.(*views.CRDSpecModel)
 				cmds = append(cmds, cmd)
 			}
+		case ReportView:
+			if m.report != nil {
+				newModel, cmd := m.report.Update(keyMsg)
+				m.report = newModel.(*views.ReportModel)
+				cmds = append(cmds, cmd)
+			}
 		case NSPickerView:
 			if m.nsPicker != nil {
 				newModel, cmd := m.nsPicker.Update(keyMsg)
 				m.nsPicker = newModel.(*views.NSPickerModel)
 				cmds = append(cmds, cmd)
 			}
+		case ContextPickerView:
+			if m.ctxPicker != nil {
+				newModel, cmd := m.ctxPicker.Update(keyMsg)
+				m.ctxPicker = newModel.(*views.ContextPickerModel)
+				cmds = append(cmds, cmd)
+			}
 		}
 		return m, tea.Batch(cmds...)
 	}
@@ -297,9 +421,63 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 	}
 
+	if m.report != nil {
+		newModel, cmd := m.report.Update(msg)
+		m.report = newModel.(*views.ReportModel)
+		cmds = append(cmds, cmd)
+	}
+
+	if m.ctxPicker != nil {
+		newModel, cmd := m.ctxPicker.Update(msg)
+		m.ctxPicker = newModel.(*views.ContextPickerModel)
+		cmds = append(cmds, cmd)
+	}
+
 	return m, tea.Batch(cmds...)
 }
 
+// switchContext rebuilds the clients for another kubeconfig context and reloads the CRD list.
+// Returning to a context restores the namespace last used in it.
+func (m Model) switchContext(name string) (tea.Model, tea.Cmd) {
+	m.contextNamespaces[m.client.Context] = namespaceChoice{namespace: m.client.Namespace, all: m.config.AllNamespaces}
+
+	cfg := *m.config
+	cfg.Context = name
+	cfg.Namespace = "" // Defaults to the namespace of the context
+	if choice, ok := m.contextNamespaces[name]; ok {
+		cfg.Namespace = choice.namespace
+		cfg.AllNamespaces = choice.all
+	}
+	client, err := m.newClient(&cfg)
+	if err != nil {
+		m.ctxPicker.SetError(err)
+		return m, nil
+	}
+	*m.config = cfg
+
+	// Views of the old context hold its client, start over with the CRD list
+	if m.crList != nil {
+		m.crList.Close()
+	}
+	m.client = client
+	m.crList = nil
+	m.crDetail = nil
+	m.crdSpec = nil
+	m.report = nil
+	m.nsPicker = nil
+	m.ctxPicker = nil
+	m.detailStack = nil
+	m.state = CRDListView
+
+	ns := client.Namespace
+	if cfg.AllNamespaces {
+		ns = "all-namespaces"
+	}
+	m.crdList = views.NewCRDListModel(client, ns, m.width, m.height, cfg.DisableCounts)
+	m.crdList.SetFavorites(m.config)
+	return m, m.crdList.Init()
+}
+
 // View renders the model
 func (m Model) View() string {
 	if !m.ready {
@@ -312,7 +490,7 @@ func (m Model) View() string {
 
 	var view string
 	switch m.state {
-	case CRDListView, NSPickerView:
+	case CRDListView, NSPickerView, ContextPickerView:
 		if m.crdList != nil {
 			view = m.crdList.View()
 		} else {
@@ -336,6 +514,12 @@ func (m Model) View() string {
 		} else {
 			view = fmt.Sprintf("\n %s Loading CRD Spec...", m.spinner.View())
 		}
+	case ReportView:
+		if m.report != nil {
+			view = m.report.View()
+		} else {
+			view = fmt.Sprintf("\n %s Loading Report...", m.spinner.View())
+		}
 	default:
 		view = "Unknown View"
 	}
@@ -345,10 +529,14 @@ func (m Model) View() string {
 		nsText = "all-namespaces"
 	}
 
-	statusBar := lipgloss.JoinHorizontal(lipgloss.Top,
+	statusItems := []string{
 		StatusBarMainStyle.Render(fmt.Sprintf("Context: %s", m.client.Context)),
 		StatusBarExtraStyle.Render(fmt.Sprintf("Namespace: %s", nsText)),
-	)
+	}
+	if m.client.ReadOnly {
+		statusItems = append(statusItems, StatusBarExtraStyle.Render("Read-only"))
+	}
+	statusBar := lipgloss.JoinHorizontal(lipgloss.Top, statusItems...)
 
 	view = lipgloss.JoinVertical(lipgloss.Left,
 		view,
@@ -359,6 +547,9 @@ func (m Model) View() string {
 	if m.state == NSPickerView && m.nsPicker != nil {
 		view = m.nsPicker.View()
 	}
+	if m.state == ContextPickerView && m.ctxPicker != nil {
+		view = m.ctxPicker.View()
+	}
 
 	if m.showHelp {
 		return lipgloss.JoinVertical(lipgloss.Left,
@@ -1,12 +1,16 @@
 package ui
 
 import (
+	"errors"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pteich/crdlens/internal/config"
 	"github.com/pteich/crdlens/internal/k8s"
+	"github.com/pteich/crdlens/internal/types"
+	"github.com/pteich/crdlens/internal/ui/views"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewModel(t *testing.T) {
@@ -46,3 +50,138 @@ func TestModel_Update_Quit(t *testing.T) {
 	assert.NotNil(t, cmd)
 	// We can't easily check if it's tea.Quit without more complex logic, but we can verify it's not nil
 }
+
+func TestModel_OpenResourceFromTree(t *testing.T) {
+	cfg := config.DefaultConfig()
+	m := NewModel(cfg, &k8s.Client{})
+	m.state = CRDetailView
+	first := views.NewCRDetailModel(m.client, cfg, types.Resource{Name: "app", UID: "app"}, 100, 50)
+	m.crDetail = first
+
+	newModel, _ := m.Update(views.OpenResourceMsg{Resource: types.Resource{Name: "child", UID: "child"}})
+	m = newModel.(Model)
+	assert.NotSame(t, first, m.crDetail)
+	assert.Len(t, m.detailStack, 1)
+
+	// Esc returns to the resource the tree was opened from, then to the list
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	assert.Same(t, first, m.crDetail)
+	assert.Equal(t, CRDetailView, m.state)
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	assert.Equal(t, CRListView, m.state)
+}
+
+func TestModel_ReportView(t *testing.T) {
+	cfg := config.DefaultConfig()
+	m := NewModel(cfg, &k8s.Client{})
+	newModel, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = newModel.(Model)
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	m = newModel.(Model)
+	assert.Equal(t, ReportView, m.state)
+	assert.NotNil(t, m.report)
+
+	report := &k8s.Report{Resources: []k8s.UnhealthyResource{
+		{CRD: "apps.example.com", Resource: types.Resource{Name: "web", UID: "web"}, Reasons: []string{"NotReady"}},
+	}}
+	newModel, _ = m.Update(views.FetchedReportMsg{Namespace: m.client.Namespace, Report: report})
+	m = newModel.(Model)
+
+	// Enter on the resource row opens the detail view, Esc returns to the dashboard
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = newModel.(Model)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	assert.Equal(t, CRDetailView, m.state)
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	assert.Equal(t, ReportView, m.state)
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	assert.Equal(t, CRDListView, m.state)
+}
+
+func TestModel_SwitchContext(t *testing.T) {
+	cfg := config.DefaultConfig()
+	m := NewModel(cfg, &k8s.Client{Context: "prod", Namespace: "default"})
+	m.newClient = func(cfg *config.Config) (*k8s.Client, error) {
+		if cfg.Context == "broken" {
+			return nil, errors.New("no such context")
+		}
+		if cfg.Namespace == "" {
+			cfg.Namespace = cfg.Context + "-default"
+		}
+		return &k8s.Client{Context: cfg.Context, Namespace: cfg.Namespace}, nil
+	}
+	newModel, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = newModel.(Model)
+
+	switchTo := func(name string) {
+		t.Helper()
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+		m = newModel.(Model)
+		assert.Equal(t, ContextPickerView, m.state)
+		newModel, _ = m.Update(views.ContextSelectedMsg{Context: name})
+		m = newModel.(Model)
+	}
+
+	switchTo("dev")
+	assert.Equal(t, CRDListView, m.state)
+	assert.Equal(t, "dev", m.client.Context)
+	assert.Equal(t, "dev-default", m.client.Namespace)
+	assert.Equal(t, "dev", cfg.Context)
+
+	newModel, _ = m.Update(views.NamespaceSelectedMsg{Namespace: "team-b"})
+	m = newModel.(Model)
+
+	// The last namespace of each context is restored
+	switchTo("prod")
+	assert.Equal(t, "default", m.client.Namespace)
+	switchTo("dev")
+	assert.Equal(t, "team-b", m.client.Namespace)
+
+	// Errors keep the current client and the picker open
+	switchTo("broken")
+	assert.Equal(t, ContextPickerView, m.state)
+	assert.Equal(t, "dev", m.client.Context)
+	assert.Contains(t, m.View(), "no such context")
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	assert.Equal(t, CRDListView, m.state)
+}
+
+func TestModel_SwitchContextIgnoresOldReplies(t *testing.T) {
+	cfg := config.DefaultConfig()
+	m := NewModel(cfg, &k8s.Client{Context: "prod", Namespace: "default"})
+	m.newClient = func(cfg *config.Config) (*k8s.Client, error) {
+		return &k8s.Client{Context: cfg.Context, Namespace: "default"}, nil
+	}
+	newModel, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = newModel.(Model)
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	m = newModel.(Model)
+	newModel, _ = m.Update(views.ContextSelectedMsg{Context: "dev"})
+	m = newModel.(Model)
+	require.Equal(t, "dev", m.client.Context)
+
+	// A slow reply of the old client doesn't replace the CRDs of the new context
+	newModel, _ = m.Update(views.FetchedCRDsMsg{Context: "prod", CRDs: []types.CRDInfo{{Name: "old.example.com"}}})
+	m = newModel.(Model)
+	assert.Empty(t, m.crdList.SelectedCRD().Name)
+
+	newModel, _ = m.Update(views.FetchedCRDsMsg{Context: "dev", CRDs: []types.CRDInfo{{Name: "new.example.com"}}})
+	m = newModel.(Model)
+	assert.Equal(t, "new.example.com", m.crdList.SelectedCRD().Name)
+
+	newModel, _ = m.Update(views.CRDCountsMsg{Context: "prod", Namespace: "default", Counts: map[string]int{"new.example.com": 7}})
+	m = newModel.(Model)
+	assert.Zero(t, m.crdList.SelectedCRD().Count)
+}
@@ -10,4 +10,6 @@ const (
 	CRDSpecView
 	HelpView
 	NSPickerView
+	ReportView
+	ContextPickerView
 )
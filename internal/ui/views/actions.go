@@ -0,0 +1,196 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pteich/crdlens/internal/k8s"
+	"github.com/pteich/crdlens/internal/types"
+)
+
+// dialogAction identifies what a confirmed dialog should trigger
+type dialogAction int
+
+const (
+	dialogNone dialogAction = iota
+	dialogSwitchNamespace
+	dialogDelete
+	dialogRemoveFinalizers
+)
+
+// deletePropagationPolicies maps the delete dialog options to propagation policies,
+// the trailing "Cancel" option has no policy
+var deletePropagationPolicies = []metav1.DeletionPropagation{
+	metav1.DeletePropagationBackground,
+	metav1.DeletePropagationForeground,
+	metav1.DeletePropagationOrphan,
+}
+
+// writeKeys are the keys of actions that modify resources in the cluster
+var writeKeys = map[string]bool{"e": true, "d": true, "R": true, "S": true, "F": true}
+
+// readOnly returns true if write actions are disabled, e.g. in offline mode
+func readOnly(client *k8s.Client) bool {
+	return client != nil && client.ReadOnly
+}
+
+// favoriteMarker prefixes the names of starred CRDs and resources
+const favoriteMarker = "★ "
+
+// clientContext returns the kubeconfig context favorites are stored under
+func clientContext(client *k8s.Client) string {
+	if client == nil {
+		return ""
+	}
+	return client.Context
+}
+
+// resourceRef returns a short kind/namespace/name reference for messages
+func resourceRef(res types.Resource) string {
+	name := res.Name
+	if res.Namespace != "" {
+		name = res.Namespace + "/" + name
+	}
+	return fmt.Sprintf("%s %s", res.Kind, name)
+}
+
+// newDeleteDialog asks for confirmation and the propagation policy
+func newDeleteDialog(res types.Resource) *ConfirmDialog {
+	message := fmt.Sprintf("Delete %s?\n\nChoose how dependents are handled:", resourceRef(res))
+	if len(res.Finalizers) > 0 {
+		message += fmt.Sprintf("\n\nThe resource has finalizers (%s) and will stay in Terminating until its controller removes them.",
+			strings.Join(res.Finalizers, ", "))
+	}
+
+	options := make([]string, 0, len(deletePropagationPolicies)+1)
+	for _, p := range deletePropagationPolicies {
+		options = append(options, string(p))
+	}
+	return NewConfirmDialog(message, append(options, "Cancel")...)
+}
+
+// newRemoveFinalizersDialog asks for a separate confirmation before dropping finalizers
+func newRemoveFinalizersDialog(res types.Resource) *ConfirmDialog {
+	message := fmt.Sprintf("Remove all finalizers from %s?\n\n%s\n\n"+
+		"Only do this if the controller responsible for them is gone. "+
+		"Cleanup of external resources will be skipped.",
+		resourceRef(res), strings.Join(res.Finalizers, "\n"))
+	return NewConfirmDialog(message, "Remove finalizers", "Cancel")
+}
+
+// terminatingSummary describes what a terminating resource is waiting for
+func terminatingSummary(res types.Resource) string {
+	if !res.IsTerminating() {
+		return ""
+	}
+
+	since := time.Since(res.DeletionTimestamp).Round(time.Second)
+	if len(res.Finalizers) == 0 {
+		return fmt.Sprintf("Terminating for %v", since)
+	}
+	return fmt.Sprintf("Terminating for %v, waiting for finalizers: %s", since, strings.Join(res.Finalizers, ", "))
+}
+
+// kstatusSummary shows the kstatus of a resource with its message
+func kstatusSummary(res types.Resource) string {
+	if res.KStatus == "" {
+		return ""
+	}
+	if res.KStatusMessage == "" {
+		return "kstatus: " + res.KStatus
+	}
+	return fmt.Sprintf("kstatus: %s (%s)", res.KStatus, res.KStatusMessage)
+}
+
+// ResourceDeletedMsg is sent when a delete request finished
+type ResourceDeletedMsg struct {
+	UID string
+	Ref string
+	Err error
+}
+
+// FinalizersRemovedMsg is sent when finalizers were removed from a resource
+type FinalizersRemovedMsg struct {
+	UID      string
+	Ref      string
+	Resource *types.Resource
+	Err      error
+}
+
+// RefreshedResourceMsg carries a re-fetched resource, Gone is set if it no longer exists
+type RefreshedResourceMsg struct {
+	UID      string
+	Seq      int // Poll the refresh belongs to, see CRDetailModel.refresh
+	Resource *types.Resource
+	Gone     bool
+	Err      error
+}
+
+// SuspendToggledMsg is sent when reconciliation of a resource was suspended or resumed
+type SuspendToggledMsg struct {
+	UID       string
+	Ref       string
+	Suspended bool
+	Resource  *types.Resource
+	Err       error
+}
+
+// suspendNotice describes the result of a successful suspend toggle
+func suspendNotice(msg SuspendToggledMsg) string {
+	if msg.Suspended {
+		return fmt.Sprintf("Suspended reconciliation of %s", msg.Ref)
+	}
+	return fmt.Sprintf("Resumed reconciliation of %s", msg.Ref)
+}
+
+// deleteResource is a command that deletes res with the given propagation policy
+func deleteResource(client *k8s.Client, res types.Resource, policy metav1.DeletionPropagation) tea.Cmd {
+	return func() tea.Msg {
+		err := client.Dynamic().Delete(context.Background(), res.GVR, res.Namespace, res.Name, k8s.DeleteOptions{
+			PropagationPolicy: policy,
+		})
+		return ResourceDeletedMsg{UID: res.UID, Ref: resourceRef(res), Err: err}
+	}
+}
+
+// removeFinalizers is a command that strips all finalizers from res
+func removeFinalizers(client *k8s.Client, res types.Resource) tea.Cmd {
+	return func() tea.Msg {
+		updated, err := client.Dynamic().RemoveFinalizers(context.Background(), res.GVR, res.Namespace, res.Name)
+		if apierrors.IsNotFound(err) {
+			// Removing the last finalizer let the API server delete the resource right away
+			err = nil
+		}
+		return FinalizersRemovedMsg{UID: res.UID, Ref: resourceRef(res), Resource: updated, Err: err}
+	}
+}
+
+// refreshResourceAfter is a command that re-fetches res after a delay
+func refreshResourceAfter(client *k8s.Client, res types.Resource, delay time.Duration, seq int) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		updated, err := client.Dynamic().GetResource(context.Background(), res.GVR, res.Namespace, res.Name)
+		if apierrors.IsNotFound(err) {
+			return RefreshedResourceMsg{UID: res.UID, Seq: seq, Gone: true}
+		}
+		if err == nil && updated.UID != res.UID {
+			// Same name, but a new object was created in the meantime
+			return RefreshedResourceMsg{UID: res.UID, Seq: seq, Gone: true}
+		}
+		return RefreshedResourceMsg{UID: res.UID, Seq: seq, Resource: updated, Err: err}
+	})
+}
+
+// toggleSuspend is a command that suspends res, or resumes it if it is already suspended
+func toggleSuspend(client *k8s.Client, res types.Resource) tea.Cmd {
+	suspend := !res.Suspended
+	return func() tea.Msg {
+		updated, err := client.Dynamic().SetSuspended(context.Background(), res, suspend)
+		return SuspendToggledMsg{UID: res.UID, Ref: resourceRef(res), Suspended: suspend, Resource: updated, Err: err}
+	}
+}
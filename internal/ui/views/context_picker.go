@@ -0,0 +1,170 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/pteich/crdlens/internal/config"
+	"github.com/pteich/crdlens/internal/k8s"
+)
+
+// ContextItem implements the list.Item interface
+type ContextItem struct {
+	k8s.ContextInfo
+	Active bool // Context of the running client
+}
+
+func (i ContextItem) FilterValue() string { return i.Name }
+
+func (i ContextItem) Title() string {
+	if i.Active {
+		return i.Name + " (active)"
+	}
+	return i.Name
+}
+
+func (i ContextItem) Description() string {
+	desc := fmt.Sprintf("cluster: %s, user: %s", i.Cluster, i.User)
+	if i.Namespace != "" {
+		desc += ", namespace: " + i.Namespace
+	}
+	return desc
+}
+
+// ContextPickerModel is the model for the kubeconfig context picker
+type ContextPickerModel struct {
+	list   list.Model
+	config *config.Config
+	active string
+	err    error
+	width  int
+	height int
+}
+
+// NewContextPickerModel creates a new context picker, active is the context of the running client
+func NewContextPickerModel(cfg *config.Config, active string, width, height int) *ContextPickerModel {
+	d := list.NewDefaultDelegate()
+	d.SetSpacing(0)
+
+	l := list.New([]list.Item{}, d, width, height)
+	l.Title = "Select Context"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(true)
+	l.Styles.Title = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 1)
+
+	return &ContextPickerModel{
+		list:   l,
+		config: cfg,
+		active: active,
+		width:  width,
+		height: height,
+	}
+}
+
+// Init initializes the model
+func (m *ContextPickerModel) Init() tea.Cmd {
+	return m.FetchContexts
+}
+
+// Update handles messages
+func (m *ContextPickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case FetchedContextsMsg:
+		m.err = msg.Err
+		items := make([]list.Item, len(msg.Contexts))
+		selected := 0
+		for i, c := range msg.Contexts {
+			items[i] = ContextItem{ContextInfo: c, Active: c.Name == m.active}
+			if c.Name == m.active {
+				selected = i
+			}
+		}
+		cmd := m.list.SetItems(items)
+		m.list.Select(selected)
+		return m, cmd
+
+	case tea.KeyMsg:
+		// Keys go to the filter input while typing
+		if m.list.FilterState() != list.Filtering && msg.String() == "enter" {
+			if i, ok := m.list.SelectedItem().(ContextItem); ok {
+				return m, func() tea.Msg {
+					return ContextSelectedMsg{Context: i.Name}
+				}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+// SetError shows an error, e.g. when the client for the picked context could not be created
+func (m *ContextPickerModel) SetError(err error) {
+	m.err = err
+}
+
+// IsFiltering returns true while the filter input captures the keys
+func (m *ContextPickerModel) IsFiltering() bool {
+	return m.list.FilterState() == list.Filtering
+}
+
+// View renders the model as an overlay
+func (m *ContextPickerModel) View() string {
+	pickerWidth := 60
+	if m.width < pickerWidth+4 {
+		pickerWidth = m.width - 4
+	}
+	pickerHeight := 20
+	if m.height < pickerHeight+4 {
+		pickerHeight = m.height - 4
+	}
+
+	m.list.SetSize(pickerWidth, pickerHeight)
+
+	content := m.list.View()
+	if m.err != nil {
+		content = lipgloss.JoinVertical(lipgloss.Left, content,
+			lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Width(pickerWidth).Render(fmt.Sprintf("Error: %v", m.err)))
+	}
+
+	overlay := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7D56F4")).
+		Padding(1, 2).
+		Width(pickerWidth + 4).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width,
+		m.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		overlay,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color("#1a1a1a")),
+	)
+}
+
+// ContextSelectedMsg is sent when a context is selected
+type ContextSelectedMsg struct {
+	Context string
+}
+
+// FetchedContextsMsg is sent when the contexts of the kubeconfig were read
+type FetchedContextsMsg struct {
+	Contexts []k8s.ContextInfo
+	Err      error
+}
+
+// FetchContexts reads the contexts from the kubeconfig
+func (m *ContextPickerModel) FetchContexts() tea.Msg {
+	contexts, err := k8s.ListContexts(m.config)
+	return FetchedContextsMsg{Contexts: contexts, Err: err}
+}
@@ -0,0 +1,38 @@
+package views
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pteich/crdlens/internal/config"
+	"github.com/pteich/crdlens/internal/k8s"
+)
+
+func TestContextPickerModel(t *testing.T) {
+	m := NewContextPickerModel(config.DefaultConfig(), "prod", 100, 40)
+	m.Update(FetchedContextsMsg{Contexts: []k8s.ContextInfo{
+		{Name: "dev", Cluster: "dev-cluster", User: "admin", Namespace: "team-a"},
+		{Name: "prod", Cluster: "prod-cluster", User: "admin", Current: true},
+	}})
+
+	// The active context is preselected
+	item, ok := m.list.SelectedItem().(ContextItem)
+	require.True(t, ok)
+	assert.Equal(t, "prod (active)", item.Title())
+	assert.Equal(t, "cluster: prod-cluster, user: admin", item.Description())
+
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, ContextSelectedMsg{Context: "dev"}, cmd())
+
+	view := m.View()
+	assert.Contains(t, view, "cluster: dev-cluster, user: admin, namespace: team-a")
+
+	m.SetError(errors.New("no such cluster"))
+	assert.Contains(t, m.View(), "Error: no such cluster")
+}
@@ -3,15 +3,21 @@ package views
 import (
 	"context"
 	"fmt"
+	"os"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/yaml"
 
+	"github.com/pteich/crdlens/internal/config"
 	"github.com/pteich/crdlens/internal/k8s"
 	"github.com/pteich/crdlens/internal/types"
 )
@@ -23,6 +29,8 @@ const (
 	DetailViewFields
 	DetailViewEvents
 	DetailViewReconcile
+	DetailViewTree
+	DetailViewManagedFields
 )
 
 func (m DetailViewMode) String() string {
@@ -35,6 +43,10 @@ func (m DetailViewMode) String() string {
 		return "Events"
 	case DetailViewReconcile:
 		return "Reconcile Status"
+	case DetailViewTree:
+		return "Tree"
+	case DetailViewManagedFields:
+		return "Managed Fields"
 	default:
 		return "Unknown"
 	}
@@ -46,6 +58,7 @@ type CRDetailModel struct {
 	eventTable table.Model
 	fieldTable table.Model
 	client     *k8s.Client
+	config     *config.Config
 	resource   types.Resource
 	events     []types.Event
 	loading    bool
@@ -69,8 +82,47 @@ type CRDetailModel struct {
 	navStack      []NavState
 	valueNavStack []ValueNavState
 	currentPath   string
+
+	// Owner tree
+	treeTable   table.Model
+	ownerTree   *k8s.OwnerTree
+	treeLines   []ownerTreeLine
+	treeLoading bool
+	treeErr     error
+
+	// Field ownership from managedFields
+	managedTable   table.Model
+	fieldOwnership []k8s.FieldOwnership
+	fieldOwners    map[string][]string // Managers by field key
+	ownershipErr   error
+
+	// Editing
+	editOpened string // Text the editor was opened with
+	editText   string // User's text kept after a failed apply
+	editErr    error
+	applying   bool
+	notice     string
+
+	// Actions
+	dialog       *ConfirmDialog
+	dialogAction dialogAction
+	actionErr    error
+	gone         bool // Resource was deleted from the cluster
+
+	// Reconcile requests
+	reconcileReq        *k8s.ReconcileRequest
+	reconcileAckedAfter time.Duration // Zero while waiting for the controller
+	reconciling         bool          // Reconcile request is being sent
+	refreshSeq          int           // Only the latest refresh continues polling
 }
 
+const (
+	// pollInterval is how often the resource is re-fetched while waiting for the cluster
+	pollInterval = 2 * time.Second
+	// reconcileTimeout is how long to wait for a controller to acknowledge a reconcile request
+	reconcileTimeout = 5 * time.Minute
+)
+
 // ValueNavState represents a state in the value navigation stack
 type ValueNavState struct {
 	Fields    []ValueField
@@ -79,7 +131,7 @@ type ValueNavState struct {
 }
 
 // NewCRDetailModel creates a new CR detail model
-func NewCRDetailModel(client *k8s.Client, resource types.Resource, width, height int) *CRDetailModel {
+func NewCRDetailModel(client *k8s.Client, cfg *config.Config, resource types.Resource, width, height int) *CRDetailModel {
 	vp := viewport.New(width, height-8) // Reserve space for header/footer
 
 	// Event Table
@@ -104,6 +156,7 @@ func NewCRDetailModel(client *k8s.Client, resource types.Resource, width, height
 		{Title: "Field", Width: 30},
 		{Title: "Value", Width: 40},
 		{Title: "Type", Width: 10},
+		{Title: "Managers", Width: 30},
 	}
 	ft := table.New(
 		table.WithColumns(fieldColumns),
@@ -121,16 +174,19 @@ func NewCRDetailModel(client *k8s.Client, resource types.Resource, width, height
 	st.SetStyles(s)
 
 	m := &CRDetailModel{
-		viewport:    vp,
-		eventTable:  et,
-		fieldTable:  ft,
-		statusTable: st,
-		client:      client,
-		resource:    resource,
-		width:       width,
-		height:      height,
-		activeView:  DetailViewReconcile,
-		currentPath: resource.Name,
+		viewport:     vp,
+		eventTable:   et,
+		fieldTable:   ft,
+		statusTable:  st,
+		treeTable:    newOwnerTreeTable(width, height),
+		managedTable: newManagedFieldsTable(width, height),
+		client:       client,
+		config:       cfg,
+		resource:     k8s.WithKStatus(resource),
+		width:        width,
+		height:       height,
+		activeView:   DetailViewReconcile,
+		currentPath:  resource.Name,
 	}
 	m.initReconcileTable()
 	return m
@@ -177,11 +233,15 @@ func (m *CRDetailModel) updateReconcileTableRows() {
 
 // Init initializes the model
 func (m *CRDetailModel) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		m.FormatYAML,
 		m.FetchEvents,
 		m.ParseFields,
-	)
+	}
+	if m.shouldPoll() {
+		cmds = append(cmds, m.refresh(pollInterval))
+	}
+	return tea.Batch(cmds...)
 }
 
 // HasNavigationHistory returns whether there is navigation history to go back to
@@ -219,7 +279,118 @@ func (m *CRDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.eventTable.SetRows(rows)
 
+	case FetchedOwnerTreeMsg:
+		if msg.UID != m.resource.UID {
+			return m, nil
+		}
+		m.treeLoading = false
+		m.treeErr = msg.Err
+		if msg.Err == nil {
+			m.setOwnerTree(msg.Tree)
+		}
+		return m, nil
+
+	case EditorFinishedMsg:
+		return m, m.handleEditorFinished(msg)
+
+	case AppliedEditMsg:
+		m.applying = false
+		m.editText = ""
+		m.editErr = nil
+		m.notice = "Changes applied"
+		m.valueNavStack = nil
+		m.statusNavStack = nil
+		m.currentPath = msg.Resource.Name
+		return m, m.setResource(*msg.Resource)
+
+	case ResourceDeletedMsg:
+		if msg.UID != m.resource.UID {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.actionErr = msg.Err
+			return m, nil
+		}
+		m.actionErr = nil
+		m.notice = "Deletion requested"
+		return m, m.refresh(0)
+
+	case FinalizersRemovedMsg:
+		if msg.UID != m.resource.UID {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.actionErr = msg.Err
+			return m, nil
+		}
+		m.actionErr = nil
+		m.notice = "Finalizers removed"
+		return m, m.refresh(0)
+
+	case SuspendToggledMsg:
+		if msg.UID != m.resource.UID {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.actionErr = msg.Err
+			return m, nil
+		}
+		m.actionErr = nil
+		m.notice = suspendNotice(msg)
+		return m, m.setResource(*msg.Resource)
+
+	case ReconcileRequestedMsg:
+		if msg.UID != m.resource.UID {
+			return m, nil
+		}
+		m.reconciling = false
+		if msg.Err != nil {
+			m.actionErr = msg.Err
+			return m, nil
+		}
+		m.actionErr = nil
+		m.notice = ""
+		m.reconcileReq = &msg.Request
+		m.reconcileAckedAfter = 0
+		m.activeView = DetailViewReconcile
+		cmd := m.setResource(*msg.Resource)
+		return m, tea.Batch(cmd, m.refresh(pollInterval))
+
+	case RefreshedResourceMsg:
+		if msg.UID != m.resource.UID || msg.Seq != m.refreshSeq || m.gone {
+			return m, nil
+		}
+		if msg.Gone {
+			m.gone = true
+			m.notice = "Resource was deleted from the cluster"
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.actionErr = msg.Err
+			return m, nil
+		}
+		cmd := m.setResource(*msg.Resource)
+		if m.shouldPoll() {
+			cmd = tea.Batch(cmd, m.refresh(pollInterval))
+		}
+		return m, cmd
+
+	case EditFailedMsg:
+		m.applying = false
+		m.editText = msg.Text
+		m.editErr = msg.Err
+		if msg.Resource != nil {
+			// Show the version the edit was rebased onto
+			return m, m.setResource(*msg.Resource)
+		}
+		return m, nil
+
 	case ParsedFieldsMsg:
+		m.fieldOwnership = msg.Ownership
+		m.fieldOwners = k8s.FieldOwners(msg.Ownership)
+		m.ownershipErr = msg.OwnershipErr
+		m.managedTable.SetRows(managedFieldsRows(m.fieldOwnership, time.Now()))
+
 		m.rootFields = msg.Fields
 		m.currentFields = m.rootFields
 		m.updateFieldTableRows()
@@ -229,14 +400,76 @@ func (m *CRDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.updateStatusTableRows()
 
 	case tea.KeyMsg:
+		if m.dialog != nil {
+			if done, choice := m.dialog.Update(msg); done {
+				return m, m.confirmDialog(choice)
+			}
+			return m, nil
+		}
+
+		if writeKeys[msg.String()] && readOnly(m.client) {
+			m.notice = k8s.ReadOnlyMessage
+			return m, nil
+		}
+
 		switch msg.String() {
+		case "e":
+			if m.gone {
+				return m, nil
+			}
+			return m, m.startEdit()
+
+		case "d":
+			if !m.gone && !m.resource.IsTerminating() {
+				m.dialog = newDeleteDialog(m.resource)
+				m.dialogAction = dialogDelete
+			}
+			return m, nil
+
+		case "R":
+			if m.gone || m.resource.IsTerminating() || m.reconciling || m.waitingForReconcile() {
+				return m, nil
+			}
+			return m, m.requestReconcile()
+
+		case "S":
+			if m.gone {
+				return m, nil
+			}
+			if k8s.DetectSuspendConvention(m.resource.Raw) == k8s.SuspendNone {
+				m.notice = "Resource does not support suspending"
+				return m, nil
+			}
+			return m, toggleSuspend(m.client, m.resource)
+
+		case "F":
+			if m.gone {
+				return m, nil
+			}
+			if len(m.resource.Finalizers) == 0 {
+				m.notice = "Resource has no finalizers"
+				return m, nil
+			}
+			m.dialog = newRemoveFinalizersDialog(m.resource)
+			m.dialogAction = dialogRemoveFinalizers
+			return m, nil
+
 		case "tab":
-			m.activeView = (m.activeView + 1) % 4
+			m.activeView = (m.activeView + 1) % 6
 			if m.activeView == DetailViewReconcile && m.reconcileTable.Rows() == nil {
 				m.initReconcileTable()
 			}
+			if m.activeView == DetailViewTree && m.ownerTree == nil && m.treeErr == nil {
+				return m, m.ShowTree()
+			}
 			return m, nil
 
+		case "r":
+			if m.activeView == DetailViewTree && !m.treeLoading {
+				m.treeLoading = true
+				return m, fetchOwnerTree(m.client, m.resource)
+			}
+
 		case "esc", "backspace":
 			if m.activeView == DetailViewFields && len(m.valueNavStack) > 0 {
 				lastState := m.valueNavStack[len(m.valueNavStack)-1]
@@ -259,7 +492,17 @@ func (m *CRDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// If no history, let parent handle it (return to list)
 
 		case "enter":
-			if m.activeView == DetailViewFields {
+			if m.activeView == DetailViewTree {
+				idx := m.treeTable.Cursor()
+				if idx >= 0 && idx < len(m.treeLines) {
+					node := m.treeLines[idx].node
+					if !node.Missing && node.Resource.UID != m.resource.UID {
+						res := node.Resource
+						return m, func() tea.Msg { return OpenResourceMsg{Resource: res} }
+					}
+				}
+				return m, nil
+			} else if m.activeView == DetailViewFields {
 				idx := m.fieldTable.Cursor()
 				if idx >= 0 && idx < len(m.currentFields) {
 					selected := m.currentFields[idx]
@@ -303,6 +546,8 @@ func (m *CRDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.viewport.Height = msg.Height - 8
 		m.eventTable.SetHeight(msg.Height - 10)
 		m.fieldTable.SetHeight(msg.Height - 10)
+		m.treeTable.SetHeight(msg.Height - 10)
+		m.managedTable.SetHeight(msg.Height - 10)
 
 		// Split view resizing
 		condHeight := 10
@@ -328,6 +573,14 @@ func (m *CRDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var cmd tea.Cmd
 		m.fieldTable, cmd = m.fieldTable.Update(msg)
 		cmds = append(cmds, cmd)
+	case DetailViewTree:
+		var cmd tea.Cmd
+		m.treeTable, cmd = m.treeTable.Update(msg)
+		cmds = append(cmds, cmd)
+	case DetailViewManagedFields:
+		var cmd tea.Cmd
+		m.managedTable, cmd = m.managedTable.Update(msg)
+		cmds = append(cmds, cmd)
 	case DetailViewReconcile:
 		// Focus management logic
 		switch m.reconcileFocus {
@@ -362,10 +615,260 @@ func (m *CRDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// confirmDialog closes the dialog and runs its action if an option was chosen
+func (m *CRDetailModel) confirmDialog(choice int) tea.Cmd {
+	action := m.dialogAction
+	m.dialog = nil
+	m.dialogAction = dialogNone
+
+	switch action {
+	case dialogDelete:
+		if choice >= 0 && choice < len(deletePropagationPolicies) {
+			return deleteResource(m.client, m.resource, deletePropagationPolicies[choice])
+		}
+	case dialogRemoveFinalizers:
+		if choice == 0 {
+			return removeFinalizers(m.client, m.resource)
+		}
+	}
+	return nil
+}
+
+// HasActiveDialog returns true while a dialog captures key presses
+func (m *CRDetailModel) HasActiveDialog() bool {
+	return m.dialog != nil
+}
+
+// shouldPoll reports whether the resource is expected to change soon and should be re-fetched
+func (m *CRDetailModel) shouldPoll() bool {
+	return m.resource.IsTerminating() || m.waitingForReconcile()
+}
+
+// refresh re-fetches the resource after a delay. Refreshes started before are ignored
+// when they arrive, so there is never more than one poll chain.
+func (m *CRDetailModel) refresh(delay time.Duration) tea.Cmd {
+	m.refreshSeq++
+	return refreshResourceAfter(m.client, m.resource, delay, m.refreshSeq)
+}
+
+// waitingForReconcile returns true until the controller acknowledged the last reconcile request
+func (m *CRDetailModel) waitingForReconcile() bool {
+	return m.reconcileReq != nil && m.reconcileAckedAfter == 0 &&
+		time.Since(m.reconcileReq.RequestedAt) < reconcileTimeout
+}
+
+// requestReconcile is a command that asks the managing controller to reconcile now
+func (m *CRDetailModel) requestReconcile() tea.Cmd {
+	annotation := config.DefaultConfig().ReconcileAnnotation
+	if m.config != nil && m.config.ReconcileAnnotation != "" {
+		annotation = m.config.ReconcileAnnotation
+	}
+
+	res := m.resource
+	req := k8s.NewReconcileRequest(res, annotation, time.Now())
+	m.reconciling = true
+	return func() tea.Msg {
+		updated, err := m.client.Dynamic().RequestReconcile(context.Background(), res, req)
+		return ReconcileRequestedMsg{UID: res.UID, Request: req, Resource: updated, Err: err}
+	}
+}
+
+// ShowTree switches to the owner tree and loads it on first use
+func (m *CRDetailModel) ShowTree() tea.Cmd {
+	m.activeView = DetailViewTree
+	if m.ownerTree != nil || m.treeLoading {
+		return nil
+	}
+	m.treeLoading = true
+	return fetchOwnerTree(m.client, m.resource)
+}
+
+// setOwnerTree shows a new owner tree, the cursor starts at the resource of the detail view
+func (m *CRDetailModel) setOwnerTree(tree *k8s.OwnerTree) {
+	m.ownerTree = tree
+	m.treeLines = flattenOwnerTree(tree.Root)
+
+	now := time.Now()
+	rows := make([]table.Row, len(m.treeLines))
+	cursor := 0
+	for i, line := range m.treeLines {
+		rows[i] = ownerTreeRow(line, m.resource.UID, now)
+		if line.node.Resource.UID == m.resource.UID {
+			cursor = i
+		}
+	}
+	m.treeTable.SetRows(rows)
+	m.treeTable.SetCursor(cursor)
+}
+
+// renderTreeView shows the owner tree or its loading state
+func (m *CRDetailModel) renderTreeView() string {
+	switch {
+	case m.treeLoading && m.ownerTree == nil:
+		return "Searching owners and children..."
+	case m.treeErr != nil:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("Error: " + m.treeErr.Error())
+	case m.ownerTree == nil:
+		return ""
+	}
+
+	view := m.treeTable.View()
+	if m.ownerTree.Skipped > 0 {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, lipgloss.NewStyle().Foreground(lipgloss.Color("241")).
+			Render(fmt.Sprintf("%d kinds could not be listed and are missing in the tree", m.ownerTree.Skipped)))
+	}
+	return view
+}
+
+// renderReconcileState shows the classified reconcile state with its reason
+func (m *CRDetailModel) renderReconcileState() string {
+	reconcile := config.DefaultConfig().Reconcile
+	if m.config != nil {
+		reconcile = m.config.Reconcile
+	}
+
+	th := reconcile.ThresholdsFor(crdName(m.resource.GVR), m.resource.ControllerManager)
+	now := time.Now()
+	warnings := k8s.CountRecentWarnings(m.events, now.Add(-k8s.RecentWarningWindow))
+	state, reason := m.resource.ClassifyReconcile(th, warnings, now)
+
+	color := lipgloss.Color("2")
+	switch state {
+	case types.ReconcileStateInFlight:
+		color = lipgloss.Color("6")
+	case types.ReconcileStateStuck:
+		color = lipgloss.Color("3")
+	case types.ReconcileStateError:
+		color = lipgloss.Color("196")
+	}
+	return lipgloss.NewStyle().Bold(true).Foreground(color).
+		Render(fmt.Sprintf("State: %s (%s)", state, reason))
+}
+
+// crdName returns the CRD name of a resource type, e.g. widgets.example.com
+func crdName(gvr schema.GroupVersionResource) string {
+	if gvr.Group == "" {
+		return gvr.Resource
+	}
+	return gvr.Resource + "." + gvr.Group
+}
+
+// renderManagedFieldsView shows the managedFields entries with the fields they own
+func (m *CRDetailModel) renderManagedFieldsView() string {
+	if m.ownershipErr != nil {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("Error: " + m.ownershipErr.Error())
+	}
+	if len(m.fieldOwnership) == 0 {
+		return "Resource has no managed fields"
+	}
+	return m.managedTable.View()
+}
+
+// setResource replaces the shown resource and re-renders its views.
+// Fields are only re-parsed if the user has not drilled down, to keep their position.
+func (m *CRDetailModel) setResource(res types.Resource) tea.Cmd {
+	m.resource = k8s.WithKStatus(res)
+	m.updateReconcileTableRows()
+
+	if m.waitingForReconcile() && k8s.ReconcileAcknowledged(res, *m.reconcileReq) {
+		m.reconcileAckedAfter = time.Since(m.reconcileReq.RequestedAt)
+	}
+
+	cmds := []tea.Cmd{m.FormatYAML}
+	if len(m.valueNavStack) == 0 && len(m.statusNavStack) == 0 {
+		cmds = append(cmds, m.ParseFields)
+	}
+	return tea.Batch(cmds...)
+}
+
+// startEdit opens the resource in the external editor, reusing the user's text after a failed apply
+func (m *CRDetailModel) startEdit() tea.Cmd {
+	if m.applying {
+		return nil
+	}
+
+	text := m.editText
+	if text == "" {
+		var err error
+		text, err = EditableYAML(m.resource.Raw)
+		if err != nil {
+			m.editErr = err
+			return nil
+		}
+	}
+
+	m.notice = ""
+	m.editOpened = text
+	return openEditor(m.resource.Name, withEditorHeader(text, m.editErr))
+}
+
+// handleEditorFinished validates the edited text and submits it
+func (m *CRDetailModel) handleEditorFinished(msg EditorFinishedMsg) tea.Cmd {
+	if msg.Err != nil {
+		if msg.Path != "" {
+			os.Remove(msg.Path)
+		}
+		m.editErr = fmt.Errorf("editor failed: %w", msg.Err)
+		return nil
+	}
+
+	text, err := readEditedFile(msg.Path)
+	if err != nil {
+		m.editErr = err
+		return nil
+	}
+
+	if strings.TrimSpace(text) == strings.TrimSpace(m.editOpened) {
+		m.editText = ""
+		m.editErr = nil
+		m.notice = "Edit cancelled, no changes made"
+		return nil
+	}
+
+	obj, err := ParseEditedYAML(text, m.resource.Raw)
+	if err != nil {
+		m.editText = text
+		m.editErr = err
+		return nil
+	}
+
+	m.applying = true
+	return m.applyEdit(obj, text)
+}
+
+// applyEdit is a command that submits the edited object to the cluster
+func (m *CRDetailModel) applyEdit(obj *unstructured.Unstructured, text string) tea.Cmd {
+	gvr := m.resource.GVR
+	return func() tea.Msg {
+		res, err := m.client.Dynamic().Update(context.Background(), gvr, obj)
+		if apierrors.IsConflict(err) {
+			return rebaseEdit(m.client, gvr, obj, text, err)
+		}
+		if err != nil {
+			return EditFailedMsg{Err: err, Text: text}
+		}
+		return AppliedEditMsg{Resource: res}
+	}
+}
+
+// rebaseEdit fetches the current version of a resource after a conflict and moves the
+// edited text onto its resourceVersion, custom resources don't accept updates without one
+func rebaseEdit(client *k8s.Client, gvr schema.GroupVersionResource, obj *unstructured.Unstructured, text string, conflict error) tea.Msg {
+	current, err := client.Dynamic().GetResource(context.Background(), gvr, obj.GetNamespace(), obj.GetName())
+	if err != nil {
+		return EditFailedMsg{Err: fmt.Errorf("%w, reloading it failed: %v", conflict, err), Text: text}
+	}
+	rebased, err := RebaseEditedYAML(text, current.Raw.GetResourceVersion())
+	if err != nil {
+		return EditFailedMsg{Err: conflict, Text: text, Resource: current}
+	}
+	return EditFailedMsg{Err: conflict, Text: rebased, Resource: current}
+}
+
 func (m *CRDetailModel) updateFieldTableRows() {
 	rows := make([]table.Row, len(m.currentFields))
 	for i, field := range m.currentFields {
-		rows[i] = field.TableRow()
+		rows[i] = append(field.TableRow(), fieldManagers(m.fieldOwners, field.Key))
 	}
 	m.fieldTable.SetRows(rows)
 }
@@ -373,7 +876,7 @@ func (m *CRDetailModel) updateFieldTableRows() {
 func (m *CRDetailModel) updateStatusTableRows() {
 	rows := make([]table.Row, len(m.currentStatusFields))
 	for i, field := range m.currentStatusFields {
-		rows[i] = field.TableRow()
+		rows[i] = append(field.TableRow(), fieldManagers(m.fieldOwners, field.Key))
 	}
 	m.statusTable.SetRows(rows)
 }
@@ -391,10 +894,13 @@ func (m *CRDetailModel) View() string {
 		}
 	}
 
-	helpText := fmt.Sprintf("[Tab: View (%s)] [Esc: Back] [Enter: Drill Down]", m.activeView.String())
+	helpText := fmt.Sprintf("[Tab: View (%s)] [Esc: Back] [Enter: Drill Down] [e: Edit] [d: Delete] [R: Reconcile] [S: Suspend]", m.activeView.String())
 	if m.activeView == DetailViewReconcile {
 		helpText += " [↑/↓: Switch]"
 	}
+	if m.activeView == DetailViewTree {
+		helpText += " [Enter: Open] [r: Reload]"
+	}
 
 	header := lipgloss.NewStyle().
 		Bold(true).
@@ -412,13 +918,56 @@ func (m *CRDetailModel) View() string {
 		content = m.fieldTable.View()
 	case DetailViewReconcile:
 		content = m.renderReconcileView()
+	case DetailViewTree:
+		content = m.renderTreeView()
+	case DetailViewManagedFields:
+		content = m.renderManagedFieldsView()
 	}
 
-	return lipgloss.JoinVertical(lipgloss.Left,
-		header,
-		"\n",
-		content,
-	)
+	parts := []string{header}
+	if banner := m.renderBanner(); banner != "" {
+		parts = append(parts, banner)
+	}
+	parts = append(parts, "\n", content)
+
+	view := lipgloss.JoinVertical(lipgloss.Left, parts...)
+	if m.dialog != nil {
+		view = m.dialog.View(m.width, m.height)
+	}
+	return view
+}
+
+// renderBanner shows the deletion state and the result of the last action.
+// Edit errors are kept until the next successful apply.
+func (m *CRDetailModel) renderBanner() string {
+	var lines []string
+	if summary := terminatingSummary(m.resource); summary != "" && !m.gone {
+		if len(m.resource.Finalizers) > 0 {
+			summary += "  [F] Remove finalizers"
+		}
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Render(summary))
+	}
+	if m.resource.Suspended && !m.gone {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("3")).
+			Render("⏸ Reconciliation is suspended  [S] Resume"))
+	}
+
+	switch {
+	case m.applying:
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Render("Applying changes..."))
+	case m.editErr != nil:
+		lines = append(lines, lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("196")).
+			Foreground(lipgloss.Color("196")).
+			Padding(0, 1).
+			Render(FormatApplyError(m.editErr)+"\n\n[e] Reopen editor with your changes"))
+	case m.actionErr != nil:
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("Error: "+m.actionErr.Error()))
+	case m.notice != "":
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Render(m.notice))
+	}
+	return strings.Join(lines, "\n")
 }
 
 func (m *CRDetailModel) renderReconcileView() string {
@@ -431,6 +980,7 @@ func (m *CRDetailModel) renderReconcileView() string {
 	controllerText := fmt.Sprintf("Controller: %s", res.ControllerManager)
 
 	summaryStyle := lipgloss.NewStyle().Margin(0, 0, 1, 0)
+	reconcileLine := m.renderReconcileRequest()
 	infoLine := lipgloss.JoinHorizontal(lipgloss.Top,
 		lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Render(driftText),
 		"  ",
@@ -451,6 +1001,9 @@ func (m *CRDetailModel) renderReconcileView() string {
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left,
+		m.renderReconcileState(),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render(kstatusSummary(res)),
+		reconcileLine,
 		summaryStyle.Render(infoLine),
 		m.reconcileTable.View(),
 		fieldsStyle.Render(fieldsText),
@@ -458,6 +1011,27 @@ func (m *CRDetailModel) renderReconcileView() string {
 	)
 }
 
+// renderReconcileRequest shows the progress of the last reconcile request
+func (m *CRDetailModel) renderReconcileRequest() string {
+	req := m.reconcileReq
+	if req == nil {
+		return ""
+	}
+
+	switch {
+	case m.reconcileAckedAfter > 0:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("2")).
+			Render(fmt.Sprintf("✔ Controller acknowledged the reconcile request after %v", m.reconcileAckedAfter.Round(time.Second)))
+	case m.waitingForReconcile():
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("6")).
+			Render(fmt.Sprintf("⟳ Reconcile requested via %s (%s) %v ago, waiting for the controller...",
+				req.Annotation, req.Controller, time.Since(req.RequestedAt).Round(time.Second)))
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("3")).
+			Render(fmt.Sprintf("No acknowledgement of the reconcile request after %v", reconcileTimeout))
+	}
+}
+
 // Messages
 type FormattedYAMLMsg struct {
 	YAML string
@@ -467,9 +1041,32 @@ type FetchedEventsMsg struct {
 	Events []types.Event
 }
 
+// ReconcileRequestedMsg is sent when the reconcile annotation was patched
+type ReconcileRequestedMsg struct {
+	UID      string
+	Request  k8s.ReconcileRequest
+	Resource *types.Resource
+	Err      error
+}
+
+// AppliedEditMsg is sent when edited changes were accepted by the cluster
+type AppliedEditMsg struct {
+	Resource *types.Resource
+}
+
+// EditFailedMsg is sent when submitting edited changes failed
+type EditFailedMsg struct {
+	Err  error
+	Text string
+	// Resource is the current version the text was rebased onto after a conflict
+	Resource *types.Resource
+}
+
 type ParsedFieldsMsg struct {
 	Fields       []ValueField
 	StatusFields []ValueField
+	Ownership    []k8s.FieldOwnership
+	OwnershipErr error
 }
 
 // FormatYAML is a command to format the resource as YAML
@@ -512,5 +1109,6 @@ func (m *CRDetailModel) ParseFields() tea.Msg {
 		statusFields = ParseValueFields(statusCopy, "status")
 	}
 
-	return ParsedFieldsMsg{Fields: fields, StatusFields: statusFields}
+	ownership, err := k8s.ExtractFieldOwnership(m.resource.Raw)
+	return ParsedFieldsMsg{Fields: fields, StatusFields: statusFields, Ownership: ownership, OwnershipErr: err}
 }
@@ -2,14 +2,17 @@ package views
 
 import (
 	"testing"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pteich/crdlens/internal/k8s"
 	"github.com/pteich/crdlens/internal/types"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func TestCRDetailModel_Update_FormattedYAML(t *testing.T) {
-	m := NewCRDetailModel(nil, types.Resource{}, 100, 100)
+	m := NewCRDetailModel(nil, nil, types.Resource{}, 100, 100)
 
 	msg := FormattedYAMLMsg{YAML: "key: value"}
 	_, cmd := m.Update(msg)
@@ -19,7 +22,7 @@ func TestCRDetailModel_Update_FormattedYAML(t *testing.T) {
 }
 
 func TestCRDetailModel_Update_FetchedEvents(t *testing.T) {
-	m := NewCRDetailModel(nil, types.Resource{}, 100, 100)
+	m := NewCRDetailModel(nil, nil, types.Resource{}, 100, 100)
 
 	events := []types.Event{
 		{
@@ -38,7 +41,7 @@ func TestCRDetailModel_Update_FetchedEvents(t *testing.T) {
 }
 
 func TestCRDetailModel_Update_ParsedFields(t *testing.T) {
-	m := NewCRDetailModel(nil, types.Resource{}, 100, 100)
+	m := NewCRDetailModel(nil, nil, types.Resource{}, 100, 100)
 
 	fields := []ValueField{
 		{Name: "foo", Value: "bar"},
@@ -52,6 +55,34 @@ func TestCRDetailModel_Update_ParsedFields(t *testing.T) {
 	assert.Equal(t, "foo", m.fieldTable.Rows()[0][0])
 }
 
+func TestCRDetailModel_ManagedFields(t *testing.T) {
+	m := NewCRDetailModel(nil, nil, types.Resource{}, 120, 40)
+
+	m.Update(ParsedFieldsMsg{
+		Fields: []ValueField{
+			{Name: "spec", Key: "spec", Children: []ValueField{{Name: "replicas", Key: "spec.replicas", Value: "3"}}},
+		},
+		Ownership: []k8s.FieldOwnership{
+			{Manager: "argocd-controller", Operation: "Apply", Paths: []string{"spec.replicas"}},
+			{Manager: "kubectl-edit", Operation: "Update", Paths: []string{"spec.replicas"}},
+		},
+	})
+
+	rows := m.managedTable.Rows()
+	assert.Len(t, rows, 4)
+	assert.Equal(t, "argocd-controller (1 fields)", rows[0][0])
+	assert.Equal(t, "Apply", rows[0][1])
+	assert.Equal(t, "   spec.replicas", rows[1][0])
+
+	// Drill down into spec, replicas is owned by both managers
+	m.activeView = DetailViewFields
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, "⚠ argocd-controller, kubectl-edit", m.fieldTable.Rows()[0][3])
+
+	m.activeView = DetailViewManagedFields
+	assert.Contains(t, m.View(), "kubectl-edit")
+}
+
 func TestCRDetailModel_FormatYAML(t *testing.T) {
 	res := types.Resource{
 		Raw: &unstructured.Unstructured{
@@ -60,7 +91,7 @@ func TestCRDetailModel_FormatYAML(t *testing.T) {
 			},
 		},
 	}
-	m := NewCRDetailModel(nil, res, 100, 100)
+	m := NewCRDetailModel(nil, nil, res, 100, 100)
 
 	msg := m.FormatYAML()
 	formattedMsg, ok := msg.(FormattedYAMLMsg)
@@ -68,3 +99,70 @@ func TestCRDetailModel_FormatYAML(t *testing.T) {
 	assert.True(t, ok)
 	assert.Contains(t, formattedMsg.YAML, "kind: Test")
 }
+
+func TestCRDetailModel_OwnerTree(t *testing.T) {
+	current := types.Resource{Name: "web-config", Kind: "ConfigMap", UID: "cm"}
+	m := NewCRDetailModel(nil, nil, current, 120, 40)
+
+	deployment := types.Resource{Name: "web", Kind: "Deployment", UID: "deploy"}
+	tree := &k8s.OwnerTree{Root: &k8s.OwnerTreeNode{
+		Resource: types.Resource{Name: "web", Kind: "App", UID: "app"},
+		Children: []*k8s.OwnerTreeNode{
+			{Resource: current},
+			{Resource: deployment, Children: []*k8s.OwnerTreeNode{
+				{Resource: types.Resource{Name: "web-7f9c", Kind: "ReplicaSet", UID: "rs"}},
+			}},
+		},
+	}}
+
+	// Trees of other resources are ignored
+	m.Update(FetchedOwnerTreeMsg{UID: "other", Tree: tree})
+	assert.Nil(t, m.ownerTree)
+
+	m.activeView = DetailViewTree
+	m.Update(FetchedOwnerTreeMsg{UID: "cm", Tree: tree})
+	rows := m.treeTable.Rows()
+	assert.Len(t, rows, 4)
+	assert.Equal(t, "├─ ❔ ConfigMap/web-config ◀", rows[1][0])
+	assert.Equal(t, "   └─ ❔ ReplicaSet/web-7f9c", rows[3][0])
+	assert.Equal(t, 1, m.treeTable.Cursor(), "cursor starts at the shown resource")
+
+	// Enter on the current resource does nothing, other nodes are opened
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if assert.NotNil(t, cmd) {
+		assert.Equal(t, OpenResourceMsg{Resource: deployment}, cmd())
+	}
+}
+
+func TestCRDetailModel_ReconcileState(t *testing.T) {
+	res := types.Resource{Name: "web", UID: "uid", Generation: 3, ObservedGeneration: 2,
+		SpecWriteTime: time.Now().Add(-time.Hour), LastStatusWrite: time.Now().Add(-time.Minute)}
+	m := NewCRDetailModel(nil, nil, res, 120, 40)
+	assert.Contains(t, m.View(), "State: Stuck (in flight for 1h0m0s (threshold 15m0s))")
+
+	// Recent Warning events are reported as errors
+	m.Update(FetchedEventsMsg{Events: []types.Event{
+		{Type: "Warning", Reason: "Failed", LastTimestamp: time.Now(), Count: 3},
+	}})
+	assert.Contains(t, m.View(), "State: Error (3 recent Warning events)")
+}
+
+func TestCRDetailModel_ReconcilePending(t *testing.T) {
+	res := types.Resource{Name: "web", UID: "uid"}
+	m := NewCRDetailModel(nil, nil, res, 120, 40)
+	m.reconcileReq = &k8s.ReconcileRequest{RequestedAt: time.Now()}
+
+	// R is ignored until the controller acknowledged the pending request
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	assert.Nil(t, cmd)
+
+	// Only the latest refresh continues polling
+	m.refreshSeq = 2
+	_, cmd = m.Update(RefreshedResourceMsg{UID: "uid", Seq: 1, Resource: &res})
+	assert.Nil(t, cmd)
+	assert.Equal(t, 2, m.refreshSeq)
+}
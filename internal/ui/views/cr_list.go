@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
@@ -11,6 +14,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/pteich/crdlens/internal/config"
 	"github.com/pteich/crdlens/internal/k8s"
 	"github.com/pteich/crdlens/internal/search"
 	"github.com/pteich/crdlens/internal/types"
@@ -24,6 +28,7 @@ const (
 	SortByDrift
 	SortByCreated
 	SortByStatus
+	SortByReconcileState
 )
 
 // String returns a human-readable sort mode name
@@ -37,6 +42,8 @@ func (s SortMode) String() string {
 		return "Created"
 	case SortByStatus:
 		return "Status"
+	case SortByReconcileState:
+		return "State"
 	default:
 		return "Unknown"
 	}
@@ -56,6 +63,8 @@ type CRListModel struct {
 	namespace    string
 	textinput    textinput.Model
 	filtering    bool
+	query        *search.Query // Last valid filter query
+	queryErr     error         // Syntax error of the current filter text
 	spinner      spinner.Model
 
 	// New: Sorting and pagination
@@ -66,25 +75,45 @@ type CRListModel struct {
 	hasMorePages  bool
 	totalShown    int
 
-	// Empty namespace dialog
-	showDialog        bool
-	dialogSelectedYes bool
+	// Confirmation dialog (empty namespace, delete, remove finalizers)
+	dialog       *ConfirmDialog
+	dialogAction dialogAction
+	dialogTarget types.Resource
+
+	// Result of the last action
+	notice    string
+	actionErr error
+
+	// Live updates
+	watchID     int64
+	watchCancel context.CancelFunc
+	watching    bool
+	watchErr    error // Why live updates stopped, e.g. missing watch permission
+
+	// Show the CRD's additionalPrinterColumns instead of the controller-aware columns
+	showPrinterColumns bool
+
+	// Server-side filtering, applied to lists and the watch
+	labelSelector  string
+	fieldSelector  string
+	selectorPrompt *SelectorPrompt
+
+	// Reconcile state classification
+	reconcile config.ReconcileConfig
+	warnings  map[string]int // Recent Warning events by resource UID
+
+	// Favorites of the client's context, starring is disabled without config
+	config        *config.Config
+	favoritesOnly bool
 }
 
+// watchSeq hands out unique IDs so events from stale watches can be ignored
+var watchSeq atomic.Int64
+
 // NewCRListModel creates a new CR list model
 func NewCRListModel(client *k8s.Client, crd types.CRDInfo, namespace string, width, height int) *CRListModel {
-	columns := []table.Column{
-		{Title: "R", Width: 2},        // Ready icon
-		{Title: "Status", Width: 8},   // Ready status
-		{Title: "Name", Width: 40},    // Resource name (wider)
-		{Title: "NS", Width: 20},      // Namespace
-		{Title: "Drift", Width: 6},    // Generation drift
-		{Title: "Ctrl", Width: 15},    // Controller manager (wider)
-		{Title: "Created", Width: 16}, // Creation date
-	}
-
 	t := table.New(
-		table.WithColumns(columns),
+		table.WithColumns(controllerColumns()),
 		table.WithFocused(true),
 		table.WithHeight(height-10),
 	)
@@ -102,7 +131,7 @@ func NewCRListModel(client *k8s.Client, crd types.CRDInfo, namespace string, wid
 	t.SetStyles(s)
 
 	ti := textinput.New()
-	ti.Placeholder = "Search resources..."
+	ti.Placeholder = "Search resources... (status:NotReady, drift>0, label:app=web)"
 	ti.Prompt = "/ "
 
 	spn := spinner.New()
@@ -121,6 +150,62 @@ func NewCRListModel(client *k8s.Client, crd types.CRDInfo, namespace string, wid
 		loading:   true,
 		sortMode:  SortByName,
 		sortAsc:   true,
+		reconcile: config.DefaultConfig().Reconcile,
+	}
+}
+
+// SetReconcileConfig sets the thresholds used to classify the reconcile state
+func (m *CRListModel) SetReconcileConfig(cfg config.ReconcileConfig) {
+	m.reconcile = cfg
+	m.updateTableRows()
+}
+
+// SetFavorites enables starring resources, the favorites are read from and saved to cfg
+func (m *CRListModel) SetFavorites(cfg *config.Config) {
+	m.config = cfg
+	m.applyFilter()
+	m.sortResources()
+	m.updateTableRows()
+}
+
+// isFavorite returns true if the resource is starred in the context of the client
+func (m *CRListModel) isFavorite(res types.Resource) bool {
+	return m.config != nil && m.config.IsFavoriteResource(clientContext(m.client), m.crd.Name, res.Namespace, res.Name)
+}
+
+// toggleFavorite stars or unstars the selected resource and saves the favorites
+func (m *CRListModel) toggleFavorite() {
+	res := m.SelectedResource()
+	if m.config == nil || res.Name == "" {
+		return
+	}
+	starred := m.config.ToggleFavoriteResource(clientContext(m.client), m.crd.Name, res.Namespace, res.Name)
+	if err := m.config.SaveFavorites(); err != nil {
+		m.actionErr = fmt.Errorf("failed to save favorites: %w", err)
+	} else {
+		m.actionErr = nil
+		if starred {
+			m.notice = fmt.Sprintf("Starred %s", resourceRef(res))
+		} else {
+			m.notice = fmt.Sprintf("Unstarred %s", resourceRef(res))
+		}
+	}
+	m.applyFilter()
+	m.sortResources()
+	m.updateTableRows()
+}
+
+// controllerColumns are the default columns with ready state, drift and controller
+func controllerColumns() []table.Column {
+	return []table.Column{
+		{Title: "R", Width: 2},        // Ready icon
+		{Title: "Status", Width: 11},  // Ready status
+		{Title: "Name", Width: 40},    // Resource name (wider)
+		{Title: "NS", Width: 20},      // Namespace
+		{Title: "Drift", Width: 6},    // Generation drift
+		{Title: "State", Width: 9},    // Reconcile state
+		{Title: "Ctrl", Width: 15},    // Controller manager (wider)
+		{Title: "Created", Width: 16}, // Creation date
 	}
 }
 
@@ -138,27 +223,50 @@ func (m *CRListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.continueToken = msg.ContinueToken
 		m.hasMorePages = msg.ContinueToken != ""
 		m.totalShown = len(msg.Resources)
-		m.filtered = m.allResources
+		m.applyFilter()
 		m.sortResources()
 		m.updateTableRows()
 
-		// Show dialog if no resources found and not in all-namespaces mode
-		if len(m.filtered) == 0 && m.namespace != "" && m.namespace != "all-namespaces" {
-			m.showDialog = true
+		// Show dialog if no resources found and not in all-namespaces mode.
+		// With selectors the namespace is rarely the reason for an empty list.
+		if len(m.filtered) == 0 && m.namespace != "" && m.namespace != "all-namespaces" && !m.hasSelectors() {
+			m.openDialog(dialogSwitchNamespace, types.Resource{}, NewConfirmDialog(
+				"No CRs found in current namespace.\n\nSwitch to all-namespaces?", "Yes", "No"))
 		}
+		return m, tea.Batch(m.startWatch(msg.ResourceVersion), m.fetchWarnings())
+
+	case WarningCountsMsg:
+		if msg.Namespace != m.namespace {
+			return m, nil
+		}
+		m.warnings = msg.Counts
+		m.sortResources()
+		m.updateTableRows()
 		return m, nil
 
 	case FetchedMoreCRsMsg:
 		m.loading = false
-		m.allResources = append(m.allResources, msg.Resources...)
+		// Skip resources the watch already delivered, they are newer than the page snapshot
+		for _, res := range msg.Resources {
+			if m.indexOf(res.UID) < 0 {
+				m.allResources = append(m.allResources, res)
+			}
+		}
 		m.continueToken = msg.ContinueToken
 		m.hasMorePages = msg.ContinueToken != ""
 		m.totalShown = len(m.allResources)
-		m.filtered = search.MatchResources(m.textinput.Value(), m.allResources)
+		m.applyFilter()
 		m.sortResources()
 		m.updateTableRows()
 		return m, nil
 
+	case ResourceEventMsg:
+		if msg.WatchID != m.watchID {
+			// Event from a watch that has been replaced or stopped
+			return m, nil
+		}
+		return m, m.applyResourceEvent(msg)
+
 	case ErrorMsg:
 		m.loading = false
 		m.err = msg.Err
@@ -170,27 +278,68 @@ func (m *CRListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.table.SetHeight(m.height - 10)
 		return m, nil
 
+	case ResourceDeletedMsg:
+		if msg.Err != nil {
+			m.actionErr = msg.Err
+			return m, nil
+		}
+		m.actionErr = nil
+		m.notice = fmt.Sprintf("Deletion of %s requested", msg.Ref)
+		return m, nil
+
+	case FinalizersRemovedMsg:
+		if msg.Err != nil {
+			m.actionErr = msg.Err
+			return m, nil
+		}
+		m.actionErr = nil
+		m.notice = fmt.Sprintf("Finalizers removed from %s", msg.Ref)
+		return m, nil
+
+	case SuspendToggledMsg:
+		if msg.Err != nil {
+			m.actionErr = msg.Err
+			return m, nil
+		}
+		m.actionErr = nil
+		m.notice = suspendNotice(msg)
+		// Don't wait for the watch, it may not be running
+		if idx := m.indexOf(msg.UID); idx >= 0 && msg.Resource != nil {
+			m.allResources[idx] = *msg.Resource
+			m.applyFilter()
+			m.sortResources()
+			m.updateTableRows()
+		}
+		return m, nil
+
 	case tea.KeyMsg:
-		// Handle empty namespace dialog
-		if m.showDialog {
-			switch msg.String() {
-			case "left", "right", "tab":
-				m.dialogSelectedYes = !m.dialogSelectedYes
-				return m, nil
-			case "enter":
-				if m.dialogSelectedYes {
-					m.showDialog = false
-					return m, func() tea.Msg {
-						return SwitchToAllNamespacesMsg{}
-					}
-				}
-				m.showDialog = false
+		// Handle confirmation dialog
+		if m.dialog != nil {
+			if done, choice := m.dialog.Update(msg); done {
+				return m, m.confirmDialog(choice)
+			}
+			return m, nil
+		}
+
+		// Handle selector prompt
+		if m.selectorPrompt != nil {
+			done, apply := m.selectorPrompt.Update(msg)
+			if !done {
 				return m, nil
-			case "esc":
-				m.showDialog = false
+			}
+			prompt := m.selectorPrompt
+			m.selectorPrompt = nil
+			if !apply {
 				return m, nil
 			}
-			return m, nil
+			if prompt.Kind() == FieldSelector {
+				m.fieldSelector = prompt.Value()
+			} else {
+				m.labelSelector = prompt.Value()
+			}
+			m.err = nil
+			m.loading = true
+			return m, m.Refresh(m.namespace)
 		}
 
 		// Handle sort menu
@@ -224,6 +373,13 @@ func (m *CRListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.sortResources()
 				m.updateTableRows()
 				return m, nil
+			case "5":
+				m.sortMode = SortByReconcileState
+				m.sortAsc = false // Error and Stuck first
+				m.showSortMenu = false
+				m.sortResources()
+				m.updateTableRows()
+				return m, nil
 			case "esc":
 				m.showSortMenu = false
 				return m, nil
@@ -238,6 +394,10 @@ func (m *CRListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 		} else {
+			if writeKeys[msg.String()] && readOnly(m.client) {
+				m.notice = k8s.ReadOnlyMessage
+				return m, nil
+			}
 			switch msg.String() {
 			case "/":
 				m.filtering = true
@@ -246,6 +406,48 @@ func (m *CRListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "s":
 				m.showSortMenu = !m.showSortMenu
 				return m, nil
+			case "p":
+				m.togglePrinterColumns()
+				return m, nil
+			case "*":
+				m.toggleFavorite()
+				return m, nil
+			case "o":
+				m.favoritesOnly = !m.favoritesOnly
+				m.applyFilter()
+				m.sortResources()
+				m.updateTableRows()
+				m.table.SetCursor(0)
+				return m, nil
+			case "l":
+				m.selectorPrompt = NewSelectorPrompt(LabelSelector, m.labelSelector)
+				return m, textinput.Blink
+			case "f":
+				m.selectorPrompt = NewSelectorPrompt(FieldSelector, m.fieldSelector)
+				return m, textinput.Blink
+			case "d":
+				if res := m.SelectedResource(); res.Name != "" {
+					m.openDialog(dialogDelete, res, newDeleteDialog(res))
+				}
+				return m, nil
+			case "F":
+				if res := m.SelectedResource(); res.Name != "" {
+					if len(res.Finalizers) == 0 {
+						m.notice = fmt.Sprintf("%s has no finalizers", resourceRef(res))
+						return m, nil
+					}
+					m.openDialog(dialogRemoveFinalizers, res, newRemoveFinalizersDialog(res))
+				}
+				return m, nil
+			case "S":
+				if res := m.SelectedResource(); res.Name != "" {
+					if k8s.DetectSuspendConvention(res.Raw) == k8s.SuspendNone {
+						m.notice = fmt.Sprintf("%s does not support suspending", resourceRef(res))
+						return m, nil
+					}
+					return m, toggleSuspend(m.client, res)
+				}
+				return m, nil
 			}
 		}
 	}
@@ -255,7 +457,7 @@ func (m *CRListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.textinput, cmd = m.textinput.Update(msg)
 
 		// Filter resources based on query
-		m.filtered = search.MatchResources(m.textinput.Value(), m.allResources)
+		m.applyFilter()
 		m.sortResources()
 		m.updateTableRows()
 		return m, cmd
@@ -281,6 +483,151 @@ func (m *CRListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmd, sCmd)
 }
 
+// openDialog shows a confirmation dialog for the given action
+func (m *CRListModel) openDialog(action dialogAction, target types.Resource, dialog *ConfirmDialog) {
+	m.dialog = dialog
+	m.dialogAction = action
+	m.dialogTarget = target
+}
+
+// confirmDialog closes the dialog and runs its action if an option was chosen
+func (m *CRListModel) confirmDialog(choice int) tea.Cmd {
+	action, target := m.dialogAction, m.dialogTarget
+	m.dialog = nil
+	m.dialogAction = dialogNone
+
+	switch action {
+	case dialogSwitchNamespace:
+		if choice == 0 {
+			return func() tea.Msg {
+				return SwitchToAllNamespacesMsg{}
+			}
+		}
+	case dialogDelete:
+		if choice >= 0 && choice < len(deletePropagationPolicies) {
+			return deleteResource(m.client, target, deletePropagationPolicies[choice])
+		}
+	case dialogRemoveFinalizers:
+		if choice == 0 {
+			return removeFinalizers(m.client, target)
+		}
+	}
+	return nil
+}
+
+// HasActiveDialog returns true while a dialog, menu or prompt captures key presses
+func (m *CRListModel) HasActiveDialog() bool {
+	return m.dialog != nil || m.showSortMenu || m.selectorPrompt != nil
+}
+
+// hasSelectors returns true if the list is filtered on the server
+func (m *CRListModel) hasSelectors() bool {
+	return m.labelSelector != "" || m.fieldSelector != ""
+}
+
+// startWatch stops any running watch and starts streaming changes after resourceVersion
+func (m *CRListModel) startWatch(resourceVersion string) tea.Cmd {
+	m.stopWatch()
+	if resourceVersion == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := m.client.Dynamic().WatchResources(ctx, m.crd.GVR, m.namespace, k8s.WatchResourcesOptions{
+		ResourceVersion: resourceVersion,
+		LabelSelector:   m.labelSelector,
+		FieldSelector:   m.fieldSelector,
+	})
+	if err != nil {
+		cancel()
+		m.watchErr = err
+		return nil
+	}
+
+	m.watchID = watchSeq.Add(1)
+	m.watchCancel = cancel
+	m.watching = true
+	m.watchErr = nil
+	return waitForResourceEvent(m.watchID, events)
+}
+
+// stopWatch cancels the running watch, if any
+func (m *CRListModel) stopWatch() {
+	if m.watchCancel != nil {
+		m.watchCancel()
+		m.watchCancel = nil
+	}
+	m.watchID = 0
+	m.watching = false
+}
+
+// applyResourceEvent merges a watch event into the list and waits for the next one
+func (m *CRListModel) applyResourceEvent(msg ResourceEventMsg) tea.Cmd {
+	ev := msg.Event
+	switch ev.Type {
+	case k8s.ResourceExpired:
+		// Watch cannot be resumed, list again which also restarts the watch
+		m.stopWatch()
+		return m.Refresh(m.namespace)
+	case k8s.ResourceWatchFailed:
+		// Listing again would fail the same way, keep the list without live updates
+		m.stopWatch()
+		m.watchErr = ev.Err
+		return nil
+	case k8s.ResourceDeleted:
+		if idx := m.indexOf(ev.Resource.UID); idx >= 0 {
+			m.allResources = append(m.allResources[:idx], m.allResources[idx+1:]...)
+		}
+	default:
+		if idx := m.indexOf(ev.Resource.UID); idx >= 0 {
+			m.allResources[idx] = ev.Resource
+		} else {
+			m.allResources = append(m.allResources, ev.Resource)
+		}
+	}
+
+	m.totalShown = len(m.allResources)
+	m.applyFilter()
+	m.sortResources()
+	m.updateTableRows()
+
+	return waitForResourceEvent(msg.WatchID, msg.events)
+}
+
+// indexOf returns the position of the resource with the given UID or -1
+func (m *CRListModel) indexOf(uid string) int {
+	for i, res := range m.allResources {
+		if res.UID == uid {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyFilter filters all resources with the query from the filter bar.
+// While the query is invalid the last valid one is used, so the list doesn't flicker while typing.
+func (m *CRListModel) applyFilter() {
+	q, err := search.ParseQuery(m.textinput.Value())
+	m.queryErr = err
+	if err == nil {
+		m.query = q
+	}
+	if m.query == nil {
+		m.filtered = m.allResources
+	} else {
+		m.filtered = m.query.Filter(m.allResources)
+	}
+	if m.favoritesOnly {
+		var favorites []types.Resource
+		for _, res := range m.filtered {
+			if m.isFavorite(res) {
+				favorites = append(favorites, res)
+			}
+		}
+		m.filtered = favorites
+	}
+}
+
 // sortResources sorts the filtered resources based on current sort mode
 func (m *CRListModel) sortResources() {
 	sort.Slice(m.filtered, func(i, j int) bool {
@@ -292,6 +639,8 @@ func (m *CRListModel) sortResources() {
 			less = m.filtered[i].CreatedAt.Before(m.filtered[j].CreatedAt)
 		case SortByStatus:
 			less = m.filtered[i].ReadyStatus() < m.filtered[j].ReadyStatus()
+		case SortByReconcileState:
+			less = m.reconcileState(m.filtered[i]) < m.reconcileState(m.filtered[j])
 		default: // SortByName
 			less = m.filtered[i].Name < m.filtered[j].Name
 		}
@@ -304,6 +653,11 @@ func (m *CRListModel) sortResources() {
 
 // updateTableRows updates the table with current filtered/sorted resources
 func (m *CRListModel) updateTableRows() {
+	if m.showPrinterColumns {
+		m.updatePrinterTableRows()
+		return
+	}
+
 	rows := make([]table.Row, len(m.filtered))
 	for i, res := range m.filtered {
 		rows[i] = m.resourceToRow(res)
@@ -311,6 +665,76 @@ func (m *CRListModel) updateTableRows() {
 	m.table.SetRows(rows)
 }
 
+// togglePrinterColumns switches between the controller-aware and the printer columns
+func (m *CRListModel) togglePrinterColumns() {
+	m.showPrinterColumns = !m.showPrinterColumns
+
+	// Rows must never have more cells than there are columns
+	cursor := m.table.Cursor()
+	m.table.SetRows(nil)
+	if !m.showPrinterColumns {
+		m.table.SetColumns(controllerColumns())
+	}
+	m.updateTableRows()
+	m.table.SetCursor(cursor)
+}
+
+// printerColumns returns the printer columns of the selected version shown by kubectl get
+func (m *CRListModel) printerColumns() []types.PrinterColumn {
+	var columns []types.PrinterColumn
+	for _, col := range m.crd.CurrentVersion().PrinterColumns {
+		if col.Priority == 0 {
+			columns = append(columns, col)
+		}
+	}
+	if len(columns) == 0 {
+		return k8s.DefaultPrinterColumns
+	}
+	return columns
+}
+
+// updatePrinterTableRows evaluates the printer columns and sizes the columns to their values
+func (m *CRListModel) updatePrinterTableRows() {
+	namespaced := m.crd.Scope != "Cluster"
+	printerColumns := m.printerColumns()
+
+	titles := []string{"Name"}
+	if namespaced {
+		titles = append(titles, "NS")
+	}
+	for _, col := range printerColumns {
+		titles = append(titles, col.Name)
+	}
+
+	now := time.Now()
+	rows := make([]table.Row, len(m.filtered))
+	for i, res := range m.filtered {
+		row := table.Row{m.displayName(res)}
+		if namespaced {
+			row = append(row, res.Namespace)
+		}
+		for _, col := range printerColumns {
+			row = append(row, k8s.PrinterColumnValue(res.Raw, col, now))
+		}
+		rows[i] = row
+	}
+
+	columns := make([]table.Column, len(titles))
+	for i, title := range titles {
+		width := len(title)
+		for _, row := range rows {
+			width = max(width, len(row[i]))
+		}
+		columns[i] = table.Column{Title: title, Width: min(max(width, 6), 40)}
+	}
+
+	cursor := m.table.Cursor()
+	m.table.SetRows(nil)
+	m.table.SetColumns(columns)
+	m.table.SetRows(rows)
+	m.table.SetCursor(cursor)
+}
+
 // resourceToRow converts a Resource to a table row with controller-aware columns
 func (m *CRListModel) resourceToRow(res types.Resource) table.Row {
 	// Format drift
@@ -339,14 +763,46 @@ func (m *CRListModel) resourceToRow(res types.Resource) table.Row {
 	return table.Row{
 		res.ReadyIcon(),
 		res.ReadyStatus(),
-		res.Name,
+		m.displayName(res),
 		ns,
 		drift,
+		m.reconcileState(res).String(),
 		ctrl,
 		created,
 	}
 }
 
+// displayName returns the name of the resource with a marker if it is starred
+func (m *CRListModel) displayName(res types.Resource) string {
+	if m.isFavorite(res) {
+		return favoriteMarker + res.Name
+	}
+	return res.Name
+}
+
+// reconcileState classifies a resource with the thresholds of its CRD and controller
+func (m *CRListModel) reconcileState(res types.Resource) types.ReconcileState {
+	th := m.reconcile.ThresholdsFor(m.crd.Name, res.ControllerManager)
+	state, _ := res.ClassifyReconcile(th, m.warnings[res.UID], time.Now())
+	return state
+}
+
+// fetchWarnings is a command that counts the recent Warning events in the namespace
+func (m *CRListModel) fetchWarnings() tea.Cmd {
+	if m.client == nil {
+		return nil
+	}
+	client, namespace := m.client, m.namespace
+	return func() tea.Msg {
+		counts, err := client.Events(namespace).CountRecentWarnings(context.Background(), time.Now().Add(-k8s.RecentWarningWindow))
+		if err != nil {
+			// Without events the state is classified from the resource alone
+			return nil
+		}
+		return WarningCountsMsg{Namespace: namespace, Counts: counts}
+	}
+}
+
 // SelectedResource returns the currently selected resource
 func (m *CRListModel) SelectedResource() types.Resource {
 	idx := m.table.Cursor()
@@ -362,7 +818,14 @@ func (m *CRListModel) View() string {
 		return fmt.Sprintf("\n %s Loading Custom Resources...", m.spinner.View())
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error fetching CRs: %v", m.err)
+		view := fmt.Sprintf("Error fetching CRs: %v", m.err)
+		if m.selectorPrompt != nil {
+			return lipgloss.JoinVertical(lipgloss.Left, view, "\n", m.selectorPrompt.View())
+		}
+		if m.hasSelectors() {
+			view += "\n\n[l] Change label selector  [f] Change field selector"
+		}
+		return view
 	}
 
 	// Title with count and sort info
@@ -376,11 +839,34 @@ func (m *CRListModel) View() string {
 		loadingIndicator = fmt.Sprintf(" %s", m.spinner.View())
 	}
 
+	selectorIndicator := ""
+	if m.labelSelector != "" {
+		selectorIndicator += fmt.Sprintf(" [Labels: %s]", m.labelSelector)
+	}
+	if m.fieldSelector != "" {
+		selectorIndicator += fmt.Sprintf(" [Fields: %s]", m.fieldSelector)
+	}
+
+	columnsIndicator := ""
+	if m.showPrinterColumns {
+		columnsIndicator = " [Columns: kubectl]"
+	}
+
+	favoritesIndicator := ""
+	if m.favoritesOnly {
+		favoritesIndicator = " [Favorites only]"
+	}
+
+	liveIndicator := ""
+	if m.watching {
+		liveIndicator = " ● live"
+	}
+
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("#7D56F4")).
 		Padding(0, 1).
-		Render(fmt.Sprintf("%s (%s) [Sort: %s]%s", m.crd.Kind, countInfo, m.sortMode.String(), loadingIndicator))
+		Render(fmt.Sprintf("%s (%s) [Sort: %s]%s%s%s%s%s", m.crd.Kind, countInfo, m.sortMode.String(), selectorIndicator, columnsIndicator, favoritesIndicator, liveIndicator, loadingIndicator))
 
 	view := lipgloss.JoinVertical(lipgloss.Left,
 		title,
@@ -394,45 +880,13 @@ func (m *CRListModel) View() string {
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(lipgloss.Color("240")).
 			Padding(0, 1).
-			Render("Sort by:\n1) Drift ↓\n2) Created ↓\n3) Status\n4) Name\n[Esc] Cancel")
+			Render("Sort by:\n1) Drift ↓\n2) Created ↓\n3) Status\n4) Name\n5) Reconcile State ↓\n[Esc] Cancel")
 		view = lipgloss.JoinVertical(lipgloss.Left, view, "\n", sortMenu)
 	}
 
-	// Show empty namespace dialog if active
-	if m.showDialog {
-		yesStyle := lipgloss.NewStyle()
-		noStyle := lipgloss.NewStyle()
-		if m.dialogSelectedYes {
-			yesStyle = yesStyle.
-				Foreground(lipgloss.Color("229")).
-				Background(lipgloss.Color("57"))
-		} else {
-			noStyle = noStyle.
-				Foreground(lipgloss.Color("229")).
-				Background(lipgloss.Color("57"))
-		}
-
-		dialog := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#7D56F4")).
-			Padding(1, 2).
-			Width(50).
-			Render(
-				"No CRs found in current namespace.\n\n" +
-					"Switch to all-namespaces?\n\n" +
-					"  " + yesStyle.Render("[ Yes ]") + "  " + noStyle.Render("[ No ]") +
-					"\n\n[←/→] Select  [Enter] Confirm  [Esc] Cancel",
-			)
-
-		view = lipgloss.Place(
-			m.width,
-			m.height,
-			lipgloss.Center,
-			lipgloss.Center,
-			dialog,
-			lipgloss.WithWhitespaceChars(" "),
-			lipgloss.WithWhitespaceForeground(lipgloss.Color("#1a1a1a")),
-		)
+	// Show confirmation dialog if active
+	if m.dialog != nil {
+		view = m.dialog.View(m.width, m.height)
 	}
 
 	if m.filtering {
@@ -441,17 +895,53 @@ func (m *CRListModel) View() string {
 			"\n",
 			m.textinput.View(),
 		)
+		if m.queryErr != nil {
+			view = lipgloss.JoinVertical(lipgloss.Left, view,
+				lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("Invalid filter: "+m.queryErr.Error()))
+		}
+	}
+
+	if m.selectorPrompt != nil {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, "\n", m.selectorPrompt.View())
+	}
+
+	// Status line for the selected resource and the last action
+	if status := m.statusLine(); status != "" {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, status)
 	}
 
 	// Footer with keybindings
 	footer := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("241")).
-		Render("[/] Search  [s] Sort  [Enter] Details  [Esc] Back")
+		Render("[/] Search  [l] Labels  [f] Fields  [s] Sort  [p] Columns  [*] Star  [o] Favorites  [d] Delete  [F] Remove Finalizers  [S] Suspend/Resume  [Enter] Details  [Esc] Back")
 	view = lipgloss.JoinVertical(lipgloss.Left, view, "\n", footer)
 
 	return view
 }
 
+// statusLine shows action results and what a terminating selection is waiting for
+func (m *CRListModel) statusLine() string {
+	var lines []string
+	if m.actionErr != nil {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("Error: "+m.actionErr.Error()))
+	} else if m.notice != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Render(m.notice))
+	}
+	if m.watchErr != nil {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Render("Live updates stopped: "+m.watchErr.Error()))
+	}
+	if summary := terminatingSummary(m.SelectedResource()); summary != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Render(summary))
+	}
+	// With the kstatus engine the message explains the Status column
+	if m.client != nil && m.client.StatusEngine == config.StatusEngineKStatus {
+		if summary := kstatusSummary(m.SelectedResource()); summary != "" {
+			lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render(summary))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // Refresh re-fetches the resources
 func (m *CRListModel) Refresh(namespace string) tea.Cmd {
 	m.namespace = namespace
@@ -459,6 +949,11 @@ func (m *CRListModel) Refresh(namespace string) tea.Cmd {
 	return m.FetchCRs
 }
 
+// Close stops live updates, call it when the list is discarded
+func (m *CRListModel) Close() {
+	m.stopWatch()
+}
+
 // IsFiltering returns true if the list is currently filtering
 func (m *CRListModel) IsFiltering() bool {
 	return m.filtering
@@ -466,8 +961,27 @@ func (m *CRListModel) IsFiltering() bool {
 
 // FetchedCRsMsg is sent when CRs are successfully fetched
 type FetchedCRsMsg struct {
-	Resources     []types.Resource
-	ContinueToken string
+	Resources       []types.Resource
+	ContinueToken   string
+	ResourceVersion string
+}
+
+// ResourceEventMsg is sent for every change streamed by the CR watch
+type ResourceEventMsg struct {
+	WatchID int64
+	Event   k8s.ResourceEvent
+	events  <-chan k8s.ResourceEvent
+}
+
+// waitForResourceEvent is a command that blocks until the watch delivers the next event
+func waitForResourceEvent(id int64, events <-chan k8s.ResourceEvent) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-events
+		if !ok {
+			return nil
+		}
+		return ResourceEventMsg{WatchID: id, Event: ev, events: events}
+	}
 }
 
 // FetchedMoreCRsMsg is sent when additional CRs are fetched (pagination)
@@ -480,14 +994,17 @@ type FetchedMoreCRsMsg struct {
 func (m *CRListModel) FetchCRs() tea.Msg {
 	dynamicSvc := m.client.Dynamic()
 	result, err := dynamicSvc.ListResourcesPaginated(context.Background(), m.crd.GVR, m.namespace, k8s.ListResourcesOptions{
-		Limit: 100,
+		Limit:         100,
+		LabelSelector: m.labelSelector,
+		FieldSelector: m.fieldSelector,
 	})
 	if err != nil {
 		return ErrorMsg{Err: err}
 	}
 	return FetchedCRsMsg{
-		Resources:     result.Resources,
-		ContinueToken: result.ContinueToken,
+		Resources:       result.Resources,
+		ContinueToken:   result.ContinueToken,
+		ResourceVersion: result.ResourceVersion,
 	}
 }
 
@@ -499,8 +1016,10 @@ func (m *CRListModel) FetchMoreCRs() tea.Msg {
 
 	dynamicSvc := m.client.Dynamic()
 	result, err := dynamicSvc.ListResourcesPaginated(context.Background(), m.crd.GVR, m.namespace, k8s.ListResourcesOptions{
-		Limit:    100,
-		Continue: m.continueToken,
+		Limit:         100,
+		Continue:      m.continueToken,
+		LabelSelector: m.labelSelector,
+		FieldSelector: m.fieldSelector,
 	})
 	if err != nil {
 		return ErrorMsg{Err: err}
@@ -513,3 +1032,9 @@ func (m *CRListModel) FetchMoreCRs() tea.Msg {
 
 // SwitchToAllNamespacesMsg is sent when user wants to switch to all namespaces
 type SwitchToAllNamespacesMsg struct{}
+
+// WarningCountsMsg carries the number of recent Warning events per resource UID
+type WarningCountsMsg struct {
+	Namespace string
+	Counts    map[string]int
+}
@@ -33,3 +33,22 @@ func TestCRListModel_Filtering(t *testing.T) {
 	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
 	assert.False(t, m.filtering)
 }
+
+func TestCRListModel_FilterQuery(t *testing.T) {
+	m := NewCRListModel(nil, types.CRDInfo{Kind: "TestKind"}, "default", 100, 100)
+	m.Update(FetchedCRsMsg{Resources: []types.Resource{
+		{Name: "abc", Namespace: "default", Conditions: []types.Condition{{Type: "Ready", Status: "True"}}},
+		{Name: "def", Namespace: "default", Conditions: []types.Condition{{Type: "Ready", Status: "False"}}},
+	}})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("status:")})
+	// Incomplete queries are reported inline and keep the last valid result
+	assert.Contains(t, m.View(), "Invalid filter: status: missing value")
+	assert.Len(t, m.filtered, 2)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("NotReady")})
+	assert.NotContains(t, m.View(), "Invalid filter")
+	assert.Len(t, m.filtered, 1)
+	assert.Equal(t, "def", m.filtered[0].Name)
+}
@@ -1,11 +1,25 @@
 package views
 
 import (
+	"errors"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pteich/crdlens/internal/config"
+	"github.com/pteich/crdlens/internal/k8s"
 	"github.com/pteich/crdlens/internal/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestCRListModel_Update_FetchedCRs(t *testing.T) {
@@ -27,9 +41,267 @@ func TestCRListModel_Update_FetchedCRs(t *testing.T) {
 	assert.Nil(t, cmd)
 	assert.False(t, m.loading)
 	assert.Equal(t, 1, len(m.table.Rows()))
-	// New column structure: [0]=R, [1]=Status, [2]=Name, [3]=NS, [4]=Drift, [5]=Ctrl, [6]=Created
+	// New column structure: [0]=R, [1]=Status, [2]=Name, [3]=NS, [4]=Drift, [5]=State, [6]=Ctrl, [7]=Created
 	assert.Equal(t, "❔", m.table.Rows()[0][0])       // Ready icon (unknown - no conditions)
 	assert.Equal(t, "Unknown", m.table.Rows()[0][1]) // Status text (new column)
 	assert.Equal(t, "test-1", m.table.Rows()[0][2])  // Name
 	assert.Equal(t, "default", m.table.Rows()[0][3]) // Namespace
 }
+
+func TestCRListModel_Update_ResourceEvents(t *testing.T) {
+	m := NewCRListModel(nil, types.CRDInfo{Kind: "TestKind"}, "default", 100, 100)
+	m.Update(FetchedCRsMsg{Resources: []types.Resource{
+		{Name: "a", Namespace: "default", UID: "uid-a"},
+		{Name: "b", Namespace: "default", UID: "uid-b"},
+	}})
+	m.watchID = 42
+
+	events := make(chan k8s.ResourceEvent)
+
+	// Added
+	m.Update(ResourceEventMsg{WatchID: 42, events: events, Event: k8s.ResourceEvent{
+		Type:     k8s.ResourceAdded,
+		Resource: types.Resource{Name: "c", Namespace: "default", UID: "uid-c"},
+	}})
+	assert.Len(t, m.filtered, 3)
+
+	// Modified replaces the existing entry
+	_, cmd := m.Update(ResourceEventMsg{WatchID: 42, events: events, Event: k8s.ResourceEvent{
+		Type:     k8s.ResourceModified,
+		Resource: types.Resource{Name: "a", Namespace: "default", UID: "uid-a", Generation: 3, ObservedGeneration: 2},
+	}})
+	assert.NotNil(t, cmd, "should keep waiting for the next event")
+	assert.Len(t, m.filtered, 3)
+	assert.Equal(t, "+1", m.table.Rows()[0][4])
+
+	// Deleted
+	m.Update(ResourceEventMsg{WatchID: 42, events: events, Event: k8s.ResourceEvent{
+		Type:     k8s.ResourceDeleted,
+		Resource: types.Resource{Name: "b", Namespace: "default", UID: "uid-b"},
+	}})
+	assert.Len(t, m.filtered, 2)
+
+	// Events from a stale watch are ignored
+	_, cmd = m.Update(ResourceEventMsg{WatchID: 7, events: events, Event: k8s.ResourceEvent{
+		Type:     k8s.ResourceDeleted,
+		Resource: types.Resource{Name: "a", Namespace: "default", UID: "uid-a"},
+	}})
+	assert.Nil(t, cmd)
+	assert.Len(t, m.filtered, 2)
+}
+
+func TestCRListModel_DeleteDialog(t *testing.T) {
+	m := NewCRListModel(nil, types.CRDInfo{Kind: "TestKind"}, "", 100, 100)
+	m.Update(FetchedCRsMsg{Resources: []types.Resource{
+		{Name: "a", Namespace: "default", UID: "uid-a", Finalizers: []string{"example.com/cleanup"}},
+	}})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	assert.True(t, m.HasActiveDialog())
+	assert.Contains(t, m.View(), "example.com/cleanup")
+
+	// Cancel is preselected
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.False(t, m.HasActiveDialog())
+
+	// Removing finalizers needs its own confirmation
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	assert.True(t, m.HasActiveDialog())
+	assert.Equal(t, dialogRemoveFinalizers, m.dialogAction)
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, m.HasActiveDialog())
+}
+
+func TestCRListModel_SuspendUnsupported(t *testing.T) {
+	m := NewCRListModel(nil, types.CRDInfo{Kind: "TestKind"}, "", 100, 100)
+	m.Update(FetchedCRsMsg{Resources: []types.Resource{
+		{Name: "a", Namespace: "default", UID: "uid-a", Kind: "TestKind"},
+	}})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	assert.Nil(t, cmd)
+	assert.Contains(t, m.View(), "does not support suspending")
+
+	suspended := types.Resource{Name: "a", Namespace: "default", UID: "uid-a", Suspended: true}
+	m.Update(SuspendToggledMsg{UID: "uid-a", Ref: "TestKind default/a", Suspended: true, Resource: &suspended})
+	assert.Equal(t, "Suspended", m.SelectedResource().ReadyStatus())
+	assert.Contains(t, m.View(), "Suspended reconciliation of TestKind default/a")
+}
+
+func TestCRListModel_ReadOnly(t *testing.T) {
+	m := NewCRListModel(&k8s.Client{ReadOnly: true}, types.CRDInfo{Kind: "TestKind"}, "", 100, 100)
+	m.Update(FetchedCRsMsg{Resources: []types.Resource{
+		{Name: "a", Namespace: "default", UID: "uid-a", Kind: "TestKind", Finalizers: []string{"example.com/cleanup"}},
+	}})
+
+	for _, key := range []rune{'d', 'F', 'S'} {
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+		assert.Nil(t, cmd)
+		assert.False(t, m.HasActiveDialog(), "no dialog for %c", key)
+		assert.Contains(t, m.View(), k8s.ReadOnlyMessage)
+	}
+}
+
+func TestCRListModel_PrinterColumns(t *testing.T) {
+	crd := types.CRDInfo{Kind: "TestKind", Scope: "Namespaced", Version: "v1", Versions: []types.CRDVersion{{
+		Name:   "v1",
+		Served: true,
+		PrinterColumns: []types.PrinterColumn{
+			{Name: "Replicas", Type: "integer", JSONPath: ".spec.replicas"},
+			{Name: "Wide", Type: "string", JSONPath: ".spec.wide", Priority: 1},
+		},
+	}}}
+	m := NewCRListModel(nil, crd, "", 100, 100)
+
+	raw := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"replicas": int64(3)},
+	}}
+	m.Update(FetchedCRsMsg{Resources: []types.Resource{
+		{Name: "a", Namespace: "default", UID: "uid-a", Raw: raw},
+	}})
+	assert.Len(t, m.table.Rows()[0], 8)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	assert.Equal(t, table.Row{"a", "default", "3"}, m.table.Rows()[0], "wide columns are hidden")
+	assert.Contains(t, m.View(), "Replicas")
+
+	// Watch events are rendered with the printer columns as well
+	m.Update(SuspendToggledMsg{UID: "uid-a", Resource: &types.Resource{Name: "a", Namespace: "default", UID: "uid-a"}})
+	assert.Equal(t, table.Row{"a", "default", ""}, m.table.Rows()[0])
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	assert.Len(t, m.table.Rows()[0], 8)
+	assert.Len(t, m.table.Columns(), 8)
+}
+
+func TestCRListModel_LabelSelector(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	newWidget := func(name, app string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("example.com/v1")
+		u.SetKind("Widget")
+		u.SetNamespace("default")
+		u.SetName(name)
+		u.SetLabels(map[string]string{"app": app})
+		return u
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "WidgetList"}, newWidget("a", "web"), newWidget("b", "db"))
+
+	m := NewCRListModel(&k8s.Client{DynamicClient: dynamicClient}, types.CRDInfo{Kind: "Widget", GVR: gvr}, "default", 100, 100)
+	defer m.Close()
+	m.Update(m.FetchCRs())
+	assert.Len(t, m.filtered, 2)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	assert.True(t, m.HasActiveDialog())
+	for _, r := range "app=web" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.HasActiveDialog())
+	assert.NotNil(t, cmd)
+
+	m.Update(cmd())
+	assert.Len(t, m.filtered, 1)
+	assert.Equal(t, "a", m.SelectedResource().Name)
+	assert.Contains(t, m.View(), "[Labels: app=web]")
+	assert.False(t, m.HasActiveDialog(), "no namespace switch dialog for filtered lists")
+}
+
+func TestCRListModel_SortByReconcileState(t *testing.T) {
+	m := NewCRListModel(nil, types.CRDInfo{Name: "widgets.example.com", Kind: "Widget"}, "default", 100, 100)
+	m.Update(FetchedCRsMsg{Resources: []types.Resource{
+		{Name: "idle", UID: "idle"},
+		{Name: "stuck", UID: "stuck", Generation: 2, ObservedGeneration: 1, SpecWriteTime: time.Now().Add(-time.Hour)},
+		{Name: "warned", UID: "warned"},
+	}})
+	m.Update(WarningCountsMsg{Namespace: "default", Counts: map[string]int{"warned": 5}})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'5'}})
+
+	var names, states []string
+	for _, row := range m.table.Rows() {
+		names = append(names, row[2])
+		states = append(states, row[5])
+	}
+	assert.Equal(t, []string{"warned", "stuck", "idle"}, names)
+	assert.Equal(t, []string{"Error", "Stuck", "Idle"}, states)
+
+	// Thresholds per CRD
+	m.SetReconcileConfig(config.ReconcileConfig{CRDs: map[string]types.ReconcileThresholds{
+		"widgets.example.com": {StuckAfter: 2 * time.Hour},
+	}})
+	assert.Equal(t, "InFlight", m.table.Rows()[1][5])
+	assert.Equal(t, "Idle", m.table.Rows()[0][5], "warning events are ignored without threshold")
+}
+
+func TestCRListModel_Favorites(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Path = filepath.Join(t.TempDir(), ".crdlens.yaml")
+
+	m := NewCRListModel(&k8s.Client{Context: "prod"}, types.CRDInfo{Name: "widgets.example.com", Kind: "Widget"}, "default", 100, 100)
+	m.SetFavorites(cfg)
+	m.Update(FetchedCRsMsg{Resources: []types.Resource{
+		{Name: "a", Namespace: "default", Kind: "Widget", UID: "a"},
+		{Name: "b", Namespace: "default", Kind: "Widget", UID: "b"},
+	}})
+
+	m.table.SetCursor(1)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'*'}})
+	assert.True(t, cfg.IsFavoriteResource("prod", "widgets.example.com", "default", "b"))
+	assert.Equal(t, "★ b", m.table.Rows()[1][2])
+	assert.Equal(t, "Starred Widget default/b", m.notice)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	assert.Len(t, m.filtered, 1)
+	assert.Equal(t, "b", m.SelectedResource().Name)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'*'}})
+	assert.Empty(t, m.filtered)
+	assert.Empty(t, cfg.Favorites)
+}
+
+func TestCRListModel_WatchFailed(t *testing.T) {
+	m := NewCRListModel(nil, types.CRDInfo{Kind: "TestKind"}, "default", 100, 100)
+	m.Update(FetchedCRsMsg{Resources: []types.Resource{{Name: "a", Namespace: "default", UID: "uid-a"}}})
+	m.watchID = 42
+	m.watching = true
+
+	_, cmd := m.Update(ResourceEventMsg{WatchID: 42, events: make(chan k8s.ResourceEvent), Event: k8s.ResourceEvent{
+		Type: k8s.ResourceWatchFailed,
+		Err:  errors.New("widgets is forbidden"),
+	}})
+	assert.Nil(t, cmd, "a failed watch must not list again")
+	assert.False(t, m.watching)
+	assert.Len(t, m.filtered, 1)
+	assert.Contains(t, m.statusLine(), "Live updates stopped: widgets is forbidden")
+}
+
+func TestCRListModel_WatchError(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "WidgetList"})
+	dynamicClient.PrependWatchReactor("widgets", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		return true, nil, apierrors.NewForbidden(gvr.GroupResource(), "", errors.New("no watch permission"))
+	})
+
+	m := NewCRListModel(&k8s.Client{DynamicClient: dynamicClient}, types.CRDInfo{Kind: "Widget", GVR: gvr}, "default", 100, 100)
+	defer m.Close()
+
+	// The watch can't be started at all
+	assert.Nil(t, m.startWatch("0"))
+	assert.False(t, m.watching)
+	assert.Contains(t, m.statusLine(), "Live updates stopped")
+
+	// The watch is refused by the API server
+	cmd := m.startWatch("5")
+	require.NotNil(t, cmd)
+	_, cmd = m.Update(cmd())
+	assert.Nil(t, cmd)
+	assert.False(t, m.watching)
+	assert.Contains(t, m.statusLine(), "Live updates stopped")
+	assert.Contains(t, m.statusLine(), "no watch permission")
+}
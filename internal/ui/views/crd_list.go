@@ -3,6 +3,7 @@ package views
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -11,6 +12,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/pteich/crdlens/internal/config"
 	"github.com/pteich/crdlens/internal/k8s"
 	"github.com/pteich/crdlens/internal/search"
 	"github.com/pteich/crdlens/internal/types"
@@ -42,6 +44,15 @@ type CRDListModel struct {
 	lastNamespace string
 	currNamespace string
 	disableCounts bool
+
+	// Version selection
+	versionPicker    *VersionPicker
+	selectedVersions map[string]string // crdName -> version picked by the user
+
+	// Favorites of the client's context, starring is disabled without config
+	config        *config.Config
+	favoritesOnly bool
+	favoritesErr  error // Error of the last save
 }
 
 // NewCRDListModel creates a new CRD list model
@@ -49,6 +60,7 @@ func NewCRDListModel(client *k8s.Client, namespace string, width, height int, di
 	columns := []table.Column{
 		{Title: "Name", Width: 40},
 		{Title: "API Group", Width: 40},
+		{Title: "Version", Width: 14},
 		{Title: "Scope", Width: 12},
 		{Title: "CR Count", Width: 10},
 	}
@@ -80,16 +92,17 @@ func NewCRDListModel(client *k8s.Client, namespace string, width, height int, di
 	spn.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#F780E2"))
 
 	return &CRDListModel{
-		table:         t,
-		client:        client,
-		textinput:     ti,
-		spinner:       spn,
-		loading:       true,
-		width:         width,
-		height:        height,
-		cachedCounts:  make(map[string]map[string]int),
-		currNamespace: namespace,
-		disableCounts: disableCounts,
+		table:            t,
+		client:           client,
+		textinput:        ti,
+		spinner:          spn,
+		loading:          true,
+		width:            width,
+		height:           height,
+		cachedCounts:     make(map[string]map[string]int),
+		currNamespace:    namespace,
+		disableCounts:    disableCounts,
+		selectedVersions: make(map[string]string),
 	}
 }
 
@@ -100,6 +113,52 @@ func (m *CRDListModel) Init() tea.Cmd {
 
 var asciiSpinner = []string{"|", "/", "-", "\\"}
 
+// SetFavorites enables starring CRDs, the favorites are read from and saved to cfg
+func (m *CRDListModel) SetFavorites(cfg *config.Config) {
+	m.config = cfg
+	m.applyFilter()
+	m.renderRows()
+}
+
+// isFavorite returns true if the CRD is starred in the context of the client
+func (m *CRDListModel) isFavorite(crd types.CRDInfo) bool {
+	return m.config != nil && m.config.IsFavoriteCRD(clientContext(m.client), crd.Name)
+}
+
+// toggleFavorite stars or unstars the selected CRD and saves the favorites.
+// The cursor stays on the CRD although it moves in the list.
+func (m *CRDListModel) toggleFavorite() {
+	crd := m.SelectedCRD()
+	if m.config == nil || crd.Name == "" {
+		return
+	}
+	m.config.ToggleFavoriteCRD(clientContext(m.client), crd.Name)
+	m.favoritesErr = m.config.SaveFavorites()
+	m.applyFilter()
+	m.renderRows()
+	for i, c := range m.filtered {
+		if c.Name == crd.Name {
+			m.table.SetCursor(i)
+			break
+		}
+	}
+}
+
+// applyFilter filters the CRDs by the search text and the favorites toggle, favorites come first
+func (m *CRDListModel) applyFilter() {
+	matched := search.MatchCRDs(m.textinput.Value(), m.allCRDs)
+	filtered := make([]types.CRDInfo, 0, len(matched))
+	for _, crd := range matched {
+		if !m.favoritesOnly || m.isFavorite(crd) {
+			filtered = append(filtered, crd)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return m.isFavorite(filtered[i]) && !m.isFavorite(filtered[j])
+	})
+	m.filtered = filtered
+}
+
 func (m *CRDListModel) renderRows() {
 	rows := make([]table.Row, len(m.filtered))
 	frame := m.tickCount % 4
@@ -112,9 +171,14 @@ func (m *CRDListModel) renderRows() {
 		} else if m.countsLoaded {
 			countStr = fmt.Sprintf("%d", crd.Count)
 		}
+		name := crd.Kind
+		if m.isFavorite(crd) {
+			name = favoriteMarker + name
+		}
 		rows[i] = table.Row{
-			crd.Kind,
+			name,
 			crd.Group,
+			versionLabel(crd),
 			crd.Scope,
 			countStr,
 		}
@@ -126,9 +190,20 @@ func (m *CRDListModel) renderRows() {
 func (m *CRDListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case FetchedCRDsMsg:
+		if msg.Context != clientContext(m.client) {
+			// Reply from the client of the context before a switch
+			return m, nil
+		}
 		m.loading = false
 		m.allCRDs = msg.CRDs
-		m.filtered = msg.CRDs
+
+		// Keep versions picked before the refresh
+		for i, crd := range m.allCRDs {
+			if version, ok := m.selectedVersions[crd.Name]; ok {
+				m.allCRDs[i] = crd.WithVersion(version)
+			}
+		}
+		m.applyFilter()
 
 		if m.disableCounts {
 			m.renderRows()
@@ -161,8 +236,8 @@ func (m *CRDListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case CRDCountsMsg:
-		if msg.Namespace != m.currNamespace {
-			// Ignore counts from a different namespace (old request)
+		if msg.Namespace != m.currNamespace || msg.Context != clientContext(m.client) {
+			// Ignore counts from a different namespace or context (old request)
 			return m, nil
 		}
 
@@ -186,6 +261,21 @@ func (m *CRDListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.renderRows()
 		return m, nil
 
+	case CRDVersionSelectedMsg:
+		m.selectedVersions[msg.CRDName] = msg.Version
+		for i, crd := range m.allCRDs {
+			if crd.Name == msg.CRDName {
+				m.allCRDs[i] = crd.WithVersion(msg.Version)
+			}
+		}
+		for i, crd := range m.filtered {
+			if crd.Name == msg.CRDName {
+				m.filtered[i] = crd.WithVersion(msg.Version)
+			}
+		}
+		m.renderRows()
+		return m, nil
+
 	case ErrorMsg:
 		m.loading = false
 		m.err = msg.Err
@@ -198,6 +288,21 @@ func (m *CRDListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.versionPicker != nil {
+			done, version := m.versionPicker.Update(msg)
+			if !done {
+				return m, nil
+			}
+			crd := m.versionPicker.crd
+			m.versionPicker = nil
+			if version == "" || version == crd.Version {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return CRDVersionSelectedMsg{CRDName: crd.Name, Version: version}
+			}
+		}
+
 		if m.filtering {
 			switch msg.String() {
 			case "esc", "enter":
@@ -209,7 +314,7 @@ func (m *CRDListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			var cmd tea.Cmd
 			m.textinput, cmd = m.textinput.Update(msg)
 
-			m.filtered = search.MatchCRDs(m.textinput.Value(), m.allCRDs)
+			m.applyFilter()
 			m.renderRows()
 			return m, cmd
 		} else {
@@ -218,6 +323,20 @@ func (m *CRDListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.filtering = true
 				m.textinput.Focus()
 				return m, tea.Batch(textinput.Blink)
+			case "v":
+				if crd := m.SelectedCRD(); crd.Name != "" {
+					m.versionPicker = NewVersionPicker(crd)
+				}
+				return m, nil
+			case "*":
+				m.toggleFavorite()
+				return m, nil
+			case "o":
+				m.favoritesOnly = !m.favoritesOnly
+				m.applyFilter()
+				m.renderRows()
+				m.table.SetCursor(0)
+				return m, nil
 			}
 		}
 	}
@@ -244,18 +363,28 @@ func (m *CRDListModel) View() string {
 	if m.err != nil {
 		return fmt.Sprintf("Error fetching CRDs: %v", m.err)
 	}
+	if m.versionPicker != nil {
+		return m.versionPicker.View(m.width, m.height)
+	}
 
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("#7D56F4")).
 		Padding(0, 1).
 		Render("Custom Resource Definitions")
+	if m.favoritesOnly {
+		title += lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(" [Favorites only]")
+	}
 
 	view := lipgloss.JoinVertical(lipgloss.Left,
 		title,
 		"\n",
 		m.table.View(),
 	)
+	if m.favoritesErr != nil {
+		view = lipgloss.JoinVertical(lipgloss.Left, view,
+			lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("Failed to save favorites: "+m.favoritesErr.Error()))
+	}
 
 	if m.filtering {
 		view = lipgloss.JoinVertical(lipgloss.Left,
@@ -281,6 +410,11 @@ func (m *CRDListModel) IsFiltering() bool {
 	return m.filtering
 }
 
+// HasActiveDialog returns true if the version picker is open
+func (m *CRDListModel) HasActiveDialog() bool {
+	return m.versionPicker != nil
+}
+
 // Refresh re-fetches the CRDs
 func (m *CRDListModel) Refresh(namespace string) tea.Cmd {
 	m.loading = true
@@ -290,7 +424,8 @@ func (m *CRDListModel) Refresh(namespace string) tea.Cmd {
 
 // Messages
 type FetchedCRDsMsg struct {
-	CRDs []types.CRDInfo
+	CRDs    []types.CRDInfo
+	Context string // Context of the client the CRDs were listed with
 }
 
 type ErrorMsg struct {
@@ -300,6 +435,7 @@ type ErrorMsg struct {
 type CRDCountsMsg struct {
 	Counts    map[string]int
 	Namespace string
+	Context   string // Context of the client the resources were counted with
 }
 
 // FetchCRDs is a command to fetch CRDs from the cluster
@@ -309,7 +445,7 @@ func (m *CRDListModel) FetchCRDs() tea.Msg {
 	if err != nil {
 		return ErrorMsg{Err: err}
 	}
-	return FetchedCRDsMsg{CRDs: crds}
+	return FetchedCRDsMsg{CRDs: crds, Context: clientContext(m.client)}
 }
 
 // FetchCRDCounts is a command to fetch counts for all CRDs (async)
@@ -357,6 +493,7 @@ func (m *CRDListModel) FetchCRDCounts(crds []types.CRDInfo, ns string) tea.Cmd {
 		return CRDCountsMsg{
 			Counts:    counts,
 			Namespace: ns,
+			Context:   clientContext(m.client),
 		}
 	}
 }
@@ -1,9 +1,11 @@
 package views
 
 import (
+	"path/filepath"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pteich/crdlens/internal/config"
 	"github.com/pteich/crdlens/internal/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -33,3 +35,39 @@ func TestCRDListModel_Filtering(t *testing.T) {
 	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
 	assert.False(t, m.filtering)
 }
+
+func TestCRDListModel_Favorites(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Path = filepath.Join(t.TempDir(), ".crdlens.yaml")
+
+	m := NewCRDListModel(nil, "", 80, 24, true)
+	m.SetFavorites(cfg)
+	m.Update(FetchedCRDsMsg{CRDs: []types.CRDInfo{
+		{Name: "certs", Kind: "Certificate"},
+		{Name: "pods", Kind: "Pod"},
+		{Name: "widgets", Kind: "Widget"},
+	}})
+
+	// Star the last CRD, it moves to the top and stays selected
+	m.table.SetCursor(2)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'*'}})
+	assert.Equal(t, "widgets", m.SelectedCRD().Name)
+	assert.Equal(t, "★ Widget", m.table.Rows()[0][0])
+	assert.Equal(t, "Certificate", m.table.Rows()[1][0])
+	assert.True(t, cfg.IsFavoriteCRD("", "widgets"))
+	assert.FileExists(t, cfg.Path)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	assert.Len(t, m.filtered, 1)
+	assert.Contains(t, m.View(), "[Favorites only]")
+
+	// Favorites survive a refresh
+	m.Update(FetchedCRDsMsg{CRDs: []types.CRDInfo{{Name: "pods", Kind: "Pod"}, {Name: "widgets", Kind: "Widget"}}})
+	assert.Len(t, m.filtered, 1)
+	assert.Equal(t, "widgets", m.SelectedCRD().Name)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'*'}})
+	assert.Empty(t, m.filtered)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	assert.Len(t, m.filtered, 2)
+}
@@ -3,15 +3,16 @@ package views
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/pteich/crdlens/internal/k8s"
 	"github.com/pteich/crdlens/internal/types"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 )
@@ -48,6 +49,21 @@ type CRDSpecModel struct {
 	selectedField   *SchemaField
 	width           int
 	height          int
+
+	versionPicker *VersionPicker
+
+	// Schema diff
+	diffView    *SchemaDiffModel
+	diffDialog  *ConfirmDialog
+	diffTargets []string // Versions offered in the diff dialog, followed by "File..." and "Cancel"
+	askingPath  bool
+	pathInput   textinput.Model
+
+	// Sample manifest
+	sampleDialog *ConfirmDialog
+	sampleView   *SampleModel
+
+	actionErr error // Last diff or sample error
 }
 
 // NewCRDSpecModel creates a new CRD spec model
@@ -56,7 +72,7 @@ func NewCRDSpecModel(client *k8s.Client, crd types.CRDInfo, width, height int) *
 
 	columns := []table.Column{
 		{Title: "Field", Width: 40},
-		{Title: "Type", Width: 20},
+		{Title: "Type", Width: 34},
 		{Title: "Required", Width: 12},
 	}
 
@@ -78,7 +94,12 @@ func NewCRDSpecModel(client *k8s.Client, crd types.CRDInfo, width, height int) *
 		Bold(false)
 	t.SetStyles(s)
 
+	pi := textinput.New()
+	pi.Placeholder = "path/to/crd.yaml"
+	pi.Prompt = "Compare with file: "
+
 	return &CRDSpecModel{
+		pathInput:   pi,
 		viewport:    vp,
 		table:       t,
 		client:      client,
@@ -111,12 +132,7 @@ func (m *CRDSpecModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		m.viewport.SetContent(string(yamlBytes))
 
-		m.rootFields = ExtractCRDSchemaFields(msg.Spec)
-		m.flatFields = FlattenSchemaFields(m.rootFields)
-		m.currentFields = m.rootFields
-
-		m.updateTableRows()
-
+		m.loadVersionFields()
 		return m, nil
 
 	case ErrorMsg:
@@ -139,7 +155,78 @@ func (m *CRDSpecModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case CRDFileLoadedMsg:
+		m.loadFileDiff(msg)
+		return m, nil
+
 	case tea.KeyMsg:
+		if m.diffDialog != nil {
+			if done, choice := m.diffDialog.Update(msg); done {
+				m.diffDialog = nil
+				return m, m.startDiff(choice)
+			}
+			return m, nil
+		}
+
+		if m.askingPath {
+			switch msg.String() {
+			case "esc":
+				m.askingPath = false
+				m.pathInput.Blur()
+				return m, nil
+			case "enter":
+				m.askingPath = false
+				m.pathInput.Blur()
+				return m, loadCRDFile(m.pathInput.Value())
+			}
+			var cmd tea.Cmd
+			m.pathInput, cmd = m.pathInput.Update(msg)
+			return m, cmd
+		}
+
+		if m.sampleDialog != nil {
+			if done, choice := m.sampleDialog.Update(msg); done {
+				m.sampleDialog = nil
+				if choice == 0 || choice == 1 {
+					m.openSample(choice == 1)
+				}
+			}
+			return m, nil
+		}
+
+		if m.sampleView != nil {
+			exit, cmd := m.sampleView.Update(msg)
+			if exit {
+				m.sampleView = nil
+			}
+			return m, cmd
+		}
+
+		if m.diffView != nil {
+			exit, cmd := m.diffView.Update(msg)
+			if exit {
+				m.diffView = nil
+			}
+			return m, cmd
+		}
+
+		if m.versionPicker != nil {
+			done, version := m.versionPicker.Update(msg)
+			if !done {
+				return m, nil
+			}
+			m.versionPicker = nil
+			if version == "" || version == m.crd.Version {
+				return m, nil
+			}
+			m.crd = m.crd.WithVersion(version)
+			m.loadVersionFields()
+			crdName := m.crd.Name
+			return m, func() tea.Msg {
+				return CRDVersionSelectedMsg{CRDName: crdName, Version: version}
+			}
+		}
+
 		if m.showFieldDetail {
 			if msg.String() == "esc" || msg.String() == "enter" {
 				m.showFieldDetail = false
@@ -149,6 +236,24 @@ func (m *CRDSpecModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if msg.String() == "v" && m.spec != nil {
+			m.versionPicker = NewVersionPicker(m.crd)
+			return m, nil
+		}
+
+		if msg.String() == "d" && m.spec != nil {
+			m.openDiffDialog()
+			return m, nil
+		}
+
+		if msg.String() == "g" && m.spec != nil {
+			m.sampleDialog = NewConfirmDialog(
+				fmt.Sprintf("Generate a sample manifest for %s %s:\n\n"+
+					"Minimal only contains required fields, Full contains all fields.", m.crd.Kind, m.crd.Version),
+				"Minimal", "Full", "Cancel")
+			return m, nil
+		}
+
 		if m.showTable {
 			if msg.String() == "tab" {
 				m.showTable = !m.showTable
@@ -226,6 +331,95 @@ func (m *CRDSpecModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// openSample generates a sample manifest for the current version and shows it
+func (m *CRDSpecModel) openSample(full bool) {
+	sample, err := GenerateSample(m.spec, m.crd.Version, full)
+	if err != nil {
+		m.actionErr = err
+		return
+	}
+
+	mode := "minimal"
+	if full {
+		mode = "full"
+	}
+	filename := fmt.Sprintf("%s-%s-%s.yaml", strings.ToLower(m.crd.Kind), m.crd.Version, mode)
+	m.sampleView = NewSampleModel(fmt.Sprintf("%s %s (%s)", m.crd.Kind, m.crd.Version, mode), filename, sample, m.width, m.height)
+}
+
+// openDiffDialog asks what the current version should be compared with
+func (m *CRDSpecModel) openDiffDialog() {
+	m.diffTargets = nil
+	for _, v := range m.spec.Spec.Versions {
+		if v.Served && v.Name != m.crd.Version {
+			m.diffTargets = append(m.diffTargets, v.Name)
+		}
+	}
+	m.actionErr = nil
+	options := append(append([]string{}, m.diffTargets...), "File...", "Cancel")
+	m.diffDialog = NewConfirmDialog(fmt.Sprintf("Compare the schema of %s %s with:", m.crd.Name, m.crd.Version), options...)
+}
+
+// startDiff acts on the choice made in the diff dialog
+func (m *CRDSpecModel) startDiff(choice int) tea.Cmd {
+	switch {
+	case choice >= 0 && choice < len(m.diffTargets):
+		target := m.diffTargets[choice]
+		diffs := DiffCRDVersions(m.spec, m.crd.Version, m.spec, target)
+		m.diffView = NewSchemaDiffModel(fmt.Sprintf("%s → %s", m.crd.Version, target), diffs, m.width, m.height)
+		return nil
+	case choice == len(m.diffTargets):
+		m.askingPath = true
+		return m.pathInput.Focus()
+	}
+	return nil
+}
+
+// loadFileDiff compares the current version with the same CRD loaded from a file
+func (m *CRDSpecModel) loadFileDiff(msg CRDFileLoadedMsg) {
+	if msg.Err != nil {
+		m.actionErr = msg.Err
+		return
+	}
+
+	var fileCRD *apiextensionsv1.CustomResourceDefinition
+	for _, crd := range msg.CRDs {
+		if crd.Name == m.spec.Name {
+			fileCRD = crd
+		}
+	}
+	if fileCRD == nil {
+		m.actionErr = fmt.Errorf("file does not contain %s", m.crd.Name)
+		return
+	}
+
+	// Compare the same version if the file still has it, otherwise its storage version
+	version := ""
+	for _, v := range fileCRD.Spec.Versions {
+		if v.Name == m.crd.Version || (version == "" && v.Storage) {
+			version = v.Name
+		}
+	}
+
+	diffs := DiffCRDVersions(m.spec, m.crd.Version, fileCRD, version)
+	m.diffView = NewSchemaDiffModel(fmt.Sprintf("cluster %s → %s %s", m.crd.Version, msg.Path, version), diffs, m.width, m.height)
+}
+
+// loadVersionFields parses the schema of the selected version and resets the navigation
+func (m *CRDSpecModel) loadVersionFields() {
+	m.rootFields = ExtractCRDSchemaFieldsForVersion(m.spec, m.crd.Version)
+	m.flatFields = FlattenSchemaFields(m.rootFields)
+	m.navStack = nil
+	m.currentPath = m.crd.Name
+	if m.isFlatView {
+		m.currentFields = m.flatFields
+	} else {
+		m.currentFields = m.rootFields
+	}
+	m.updateTableRows()
+	m.table.SetCursor(0)
+}
+
 func (m *CRDSpecModel) toggleFlatView() {
 	m.isFlatView = !m.isFlatView
 
@@ -264,6 +458,21 @@ func (m *CRDSpecModel) View() string {
 			Foreground(lipgloss.Color("196")).
 			Render(fmt.Sprintf("Error fetching CRD spec: %v", m.err))
 	}
+	if m.versionPicker != nil {
+		return m.versionPicker.View(m.width, m.height)
+	}
+	if m.diffDialog != nil {
+		return m.diffDialog.View(m.width, m.height)
+	}
+	if m.sampleDialog != nil {
+		return m.sampleDialog.View(m.width, m.height)
+	}
+	if m.sampleView != nil {
+		return m.sampleView.View()
+	}
+	if m.diffView != nil {
+		return m.diffView.View()
+	}
 
 	viewMode := "YAML"
 	if m.showTable {
@@ -274,7 +483,7 @@ func (m *CRDSpecModel) View() string {
 		}
 	}
 
-	titleText := fmt.Sprintf("CRD Spec: %s", m.crd.Name)
+	titleText := fmt.Sprintf("CRD Spec: %s (%s)", m.crd.Name, m.crd.Version)
 	if m.showTable && !m.isFlatView && len(m.navStack) > 0 {
 		titleText = fmt.Sprintf("CRD Spec: %s", m.currentPath)
 	}
@@ -283,12 +492,13 @@ func (m *CRDSpecModel) View() string {
 		Bold(true).
 		Foreground(lipgloss.Color("#7D56F4")).
 		Padding(0, 1).
-		Render(fmt.Sprintf("%s  [Tab: View (%s)] [f: Toggle Flat] [Enter: Drill/Detail] [Esc: Back]", titleText, viewMode))
+		Render(fmt.Sprintf("%s  [Tab: View (%s)] [f: Toggle Flat] [v: Version] [d: Diff] [g: Sample] [Enter: Drill/Detail] [Esc: Back]", titleText, viewMode))
 
 	var baseView string
 	if m.showTable {
 		baseView = lipgloss.JoinVertical(lipgloss.Left,
 			title,
+			m.renderVersionInfo(),
 			"\n",
 			m.table.View(),
 		)
@@ -300,6 +510,13 @@ func (m *CRDSpecModel) View() string {
 		)
 	}
 
+	if m.askingPath {
+		baseView = lipgloss.JoinVertical(lipgloss.Left, baseView, "\n", m.pathInput.View())
+	} else if m.actionErr != nil {
+		baseView = lipgloss.JoinVertical(lipgloss.Left, baseView, "\n",
+			lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("Error: "+m.actionErr.Error()))
+	}
+
 	if m.showFieldDetail && m.selectedField != nil {
 		return m.renderFieldDetailOverlay(baseView)
 	}
@@ -307,6 +524,39 @@ func (m *CRDSpecModel) View() string {
 	return baseView
 }
 
+// renderVersionInfo lists the versions of the CRD and how they are converted
+func (m *CRDSpecModel) renderVersionInfo() string {
+	if len(m.crd.Versions) == 0 {
+		return ""
+	}
+
+	versions := make([]string, 0, len(m.crd.Versions))
+	for _, v := range m.crd.Versions {
+		if v.Served {
+			versions = append(versions, v.Name+versionTags(v))
+		}
+	}
+
+	info := lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Padding(0, 1).
+		Render(fmt.Sprintf("Versions: %s  Conversion: %s", strings.Join(versions, ", "), m.crd.Conversion))
+
+	if current := m.crd.CurrentVersion(); current.Deprecated {
+		warning := "Version " + current.Name + " is deprecated"
+		if current.DeprecationWarning != "" {
+			warning = current.DeprecationWarning
+		}
+		info = lipgloss.JoinVertical(lipgloss.Left, info,
+			lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Padding(0, 1).Render("⚠ "+warning))
+	}
+	return info
+}
+
+// HasActiveDialog returns true if a picker, dialog or prompt captures the keys
+func (m *CRDSpecModel) HasActiveDialog() bool {
+	return m.versionPicker != nil || m.diffDialog != nil || m.askingPath || m.sampleDialog != nil ||
+		(m.sampleView != nil && m.sampleView.IsPrompting())
+}
+
 // IsShowingFieldDetail returns whether the field detail overlay is currently shown
 func (m *CRDSpecModel) IsShowingFieldDetail() bool {
 	return m.showFieldDetail
@@ -314,7 +564,7 @@ func (m *CRDSpecModel) IsShowingFieldDetail() bool {
 
 // HasNavigationHistory returns whether there is navigation history to go back to
 func (m *CRDSpecModel) HasNavigationHistory() bool {
-	return !m.isFlatView && len(m.navStack) > 0
+	return m.diffView != nil || m.sampleView != nil || (!m.isFlatView && len(m.navStack) > 0)
 }
 
 func (m *CRDSpecModel) renderFieldDetailOverlay(baseView string) string {
@@ -353,14 +603,54 @@ func (m *CRDSpecModel) renderFieldDetailOverlay(baseView string) string {
 		Align(lipgloss.Right)
 
 	// Content Construction
-	content := lipgloss.JoinVertical(lipgloss.Left,
+	valueWidth := overlayWidth - 4 - 12
+	row := func(label, value string) string {
+		return lipgloss.JoinHorizontal(lipgloss.Left, labelStyle.Render(label), valueStyle.Width(valueWidth).Render(value))
+	}
+
+	f := m.selectedField
+	rows := []string{
 		titleStyle.Render("Field Details"),
-		lipgloss.JoinHorizontal(lipgloss.Left, labelStyle.Render("Path:"), valueStyle.Render(m.selectedField.FieldPath)),
-		lipgloss.JoinHorizontal(lipgloss.Left, labelStyle.Render("Type:"), valueStyle.Render(m.selectedField.Type)),
-		lipgloss.JoinHorizontal(lipgloss.Left, labelStyle.Render("Required:"), valueStyle.Render(required)),
-		descStyle.Render(m.selectedField.Description),
-		helpStyle.Render("esc or enter to close"),
-	)
+		row("Path:", f.FieldPath),
+		row("Type:", f.Type),
+		row("Required:", required),
+	}
+	if f.Format != "" {
+		rows = append(rows, row("Format:", f.Format))
+	}
+	if f.Default != "" {
+		rows = append(rows, row("Default:", f.Default))
+	}
+	if len(f.Enum) > 0 {
+		rows = append(rows, row("Enum:", strings.Join(f.Enum, ", ")))
+	}
+	if f.Pattern != "" {
+		rows = append(rows, row("Pattern:", f.Pattern))
+	}
+	if len(f.Limits) > 0 {
+		rows = append(rows, row("Limits:", strings.Join(f.Limits, "\n")))
+	}
+	if f.Nullable {
+		rows = append(rows, row("Nullable:", "Yes"))
+	}
+	if len(f.Extensions) > 0 {
+		rows = append(rows, row("Extensions:", strings.Join(f.Extensions, "\n")))
+	}
+	rows = append(rows, descStyle.Render(f.Description))
+
+	if len(f.Validations) > 0 {
+		ruleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00D9FF")).Width(overlayWidth - 6)
+		rows = append(rows, labelStyle.Width(0).MarginTop(1).Render("Validation Rules:"))
+		for _, v := range f.Validations {
+			rows = append(rows, ruleStyle.Render("• "+v.Rule))
+			if msg := validationMessage(v); msg != "" {
+				rows = append(rows, descStyle.MarginTop(0).Width(overlayWidth-6).PaddingLeft(2).Render(msg))
+			}
+		}
+	}
+
+	rows = append(rows, helpStyle.Render("esc or enter to close"))
+	content := lipgloss.JoinVertical(lipgloss.Left, rows...)
 
 	// Overlay Box
 	overlay := lipgloss.NewStyle().
@@ -382,19 +672,51 @@ func (m *CRDSpecModel) renderFieldDetailOverlay(baseView string) string {
 	)
 }
 
+// validationMessage describes what happens when a CEL rule fails
+func validationMessage(v ValidationRule) string {
+	msg := v.Message
+	if msg == "" && v.MessageExpression != "" {
+		msg = "= " + v.MessageExpression
+	}
+	var details []string
+	if v.FieldPath != "" {
+		details = append(details, "field: "+v.FieldPath)
+	}
+	if v.Reason != "" {
+		details = append(details, "reason: "+v.Reason)
+	}
+	if len(details) > 0 {
+		msg = strings.TrimSpace(msg + " (" + strings.Join(details, ", ") + ")")
+	}
+	return msg
+}
+
 // Messages
 type FetchedCRDSpecMsg struct {
 	Spec *apiextensionsv1.CustomResourceDefinition
 }
 
-// FetchCRDSpec is a command to fetch the CRD spec from the cluster
-func (m *CRDSpecModel) FetchCRDSpec() tea.Msg {
-	config, err := apiextensionsclientset.NewForConfig(m.client.Config)
-	if err != nil {
-		return ErrorMsg{Err: err}
+// CRDFileLoadedMsg carries the CRDs read from a file for a diff
+type CRDFileLoadedMsg struct {
+	Path string
+	CRDs []*apiextensionsv1.CustomResourceDefinition
+	Err  error
+}
+
+// loadCRDFile is a command that reads the CRDs from a file or directory
+func loadCRDFile(path string) tea.Cmd {
+	return func() tea.Msg {
+		crds, err := k8s.LoadCRDs(path)
+		if err == nil && len(crds) == 0 {
+			err = fmt.Errorf("no CRDs found in %s", path)
+		}
+		return CRDFileLoadedMsg{Path: path, CRDs: crds, Err: err}
 	}
+}
 
-	spec, err := config.ApiextensionsV1().CustomResourceDefinitions().Get(context.Background(), m.crd.Name, metav1.GetOptions{})
+// FetchCRDSpec is a command to fetch the CRD spec from the cluster
+func (m *CRDSpecModel) FetchCRDSpec() tea.Msg {
+	spec, err := m.client.ApiextensionsClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.Background(), m.crd.Name, metav1.GetOptions{})
 	if err != nil {
 		return ErrorMsg{Err: err}
 	}
@@ -0,0 +1,51 @@
+package views
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/pteich/crdlens/internal/types"
+)
+
+func TestCRDSpecModel_FieldDetailConstraints(t *testing.T) {
+	maxLen := int64(63)
+	spec := &apiextensionsv1.CustomResourceDefinition{
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:   "v1",
+				Served: true,
+				Schema: &apiextensionsv1.CustomResourceValidation{
+					OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"host": {
+								Type:      "string",
+								Format:    "hostname",
+								MaxLength: &maxLen,
+								Enum:      []apiextensionsv1.JSON{{Raw: []byte(`"a.example.com"`)}},
+								XValidations: apiextensionsv1.ValidationRules{
+									{Rule: "self.endsWith('.example.com')", Message: "must be an example.com host"},
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+	}
+
+	m := NewCRDSpecModel(nil, types.CRDInfo{Name: "widgets.example.com", Version: "v1"}, 120, 60)
+	m.Update(FetchedCRDSpecMsg{Spec: spec})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.True(t, m.IsShowingFieldDetail())
+	view := m.View()
+	assert.Contains(t, view, "hostname")
+	assert.Contains(t, view, "maxLength: 63")
+	assert.Contains(t, view, `"a.example.com"`)
+	assert.Contains(t, view, "self.endsWith('.example.com')")
+	assert.Contains(t, view, "must be an example.com host")
+}
@@ -0,0 +1,81 @@
+package views
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DialogCancelled is returned as choice when the dialog was dismissed with esc
+const DialogCancelled = -1
+
+// ConfirmDialog is a modal dialog with a message and a row of options
+type ConfirmDialog struct {
+	message  string
+	options  []string
+	selected int
+}
+
+// NewConfirmDialog creates a dialog, the last option is preselected so
+// destructive choices always need an explicit selection
+func NewConfirmDialog(message string, options ...string) *ConfirmDialog {
+	return &ConfirmDialog{
+		message:  message,
+		options:  options,
+		selected: len(options) - 1,
+	}
+}
+
+// Update handles a key press. It returns done=true once the user confirmed
+// an option (choice is its index) or dismissed the dialog (DialogCancelled).
+func (d *ConfirmDialog) Update(msg tea.KeyMsg) (done bool, choice int) {
+	switch msg.String() {
+	case "left", "shift+tab":
+		d.selected = (d.selected - 1 + len(d.options)) % len(d.options)
+	case "right", "tab":
+		d.selected = (d.selected + 1) % len(d.options)
+	case "enter":
+		return true, d.selected
+	case "esc":
+		return true, DialogCancelled
+	}
+	return false, 0
+}
+
+// View renders the dialog centered in the given area
+func (d *ConfirmDialog) View(width, height int) string {
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57"))
+
+	buttons := make([]string, len(d.options))
+	for i, opt := range d.options {
+		label := "[ " + opt + " ]"
+		if i == d.selected {
+			label = selectedStyle.Render(label)
+		}
+		buttons[i] = label
+	}
+
+	dialog := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7D56F4")).
+		Padding(1, 2).
+		Width(60).
+		Render(
+			d.message + "\n\n" +
+				"  " + strings.Join(buttons, "  ") +
+				"\n\n[←/→] Select  [Enter] Confirm  [Esc] Cancel",
+		)
+
+	return lipgloss.Place(
+		width,
+		height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color("#1a1a1a")),
+	)
+}
@@ -0,0 +1,32 @@
+package views
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirmDialog(t *testing.T) {
+	d := NewConfirmDialog("Delete?", "Background", "Foreground", "Cancel")
+
+	// Last option is preselected
+	done, choice := d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, done)
+	assert.Equal(t, 2, choice)
+
+	// Moving right wraps around to the first option
+	d = NewConfirmDialog("Delete?", "Background", "Foreground", "Cancel")
+	done, _ = d.Update(tea.KeyMsg{Type: tea.KeyRight})
+	assert.False(t, done)
+	done, choice = d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, done)
+	assert.Equal(t, 0, choice)
+
+	// Esc cancels
+	done, choice = d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, done)
+	assert.Equal(t, DialogCancelled, choice)
+
+	assert.Contains(t, d.View(80, 20), "Delete?")
+}
@@ -0,0 +1,181 @@
+package views
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// editorHeaderPrefix marks comment lines crdlens adds on top of the edited file
+const editorHeaderPrefix = "# crdlens: "
+
+// EditorFinishedMsg is sent when the external editor exits
+type EditorFinishedMsg struct {
+	Path string
+	Err  error
+}
+
+// EditableYAML renders an object for editing, without managedFields and status
+func EditableYAML(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", errors.New("resource has no content")
+	}
+
+	edit := obj.DeepCopy()
+	unstructured.RemoveNestedField(edit.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(edit.Object, "status")
+
+	y, err := yaml.Marshal(edit.Object)
+	if err != nil {
+		return "", err
+	}
+	return string(y), nil
+}
+
+// ParseEditedYAML parses the edited text back into an object.
+// The status of the original object is carried over because it was hidden from the user
+// and would otherwise be wiped for CRDs without a status subresource.
+func ParseEditedYAML(text string, original *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(text), &obj.Object); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if len(obj.Object) == 0 {
+		return nil, errors.New("edited resource is empty")
+	}
+
+	if original != nil {
+		if obj.GetName() != original.GetName() || obj.GetNamespace() != original.GetNamespace() {
+			return nil, errors.New("name and namespace cannot be changed")
+		}
+		if status, found, _ := unstructured.NestedFieldNoCopy(original.Object, "status"); found {
+			_ = unstructured.SetNestedField(obj.Object, status, "status")
+		}
+	}
+
+	return obj, nil
+}
+
+// RebaseEditedYAML sets metadata.resourceVersion of the edited text, so changes made
+// against an outdated version can be applied on top of the current one
+func RebaseEditedYAML(text, resourceVersion string) (string, error) {
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(text), &obj.Object); err != nil {
+		return "", fmt.Errorf("invalid YAML: %w", err)
+	}
+	if len(obj.Object) == 0 {
+		return "", errors.New("edited resource is empty")
+	}
+	obj.SetResourceVersion(resourceVersion)
+
+	y, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", err
+	}
+	return string(y), nil
+}
+
+// stripEditorHeader removes the comment lines crdlens put on top of the file
+func stripEditorHeader(text string) string {
+	lines := strings.Split(text, "\n")
+	i := 0
+	for i < len(lines) && strings.HasPrefix(lines[i], editorHeaderPrefix) {
+		i++
+	}
+	return strings.Join(lines[i:], "\n")
+}
+
+// withEditorHeader prepends err as comment lines, like kubectl edit does
+func withEditorHeader(text string, err error) string {
+	if err == nil {
+		return text
+	}
+
+	var b strings.Builder
+	b.WriteString(editorHeaderPrefix + "applying your changes failed, fix them and save again\n")
+	for _, line := range strings.Split(FormatApplyError(err), "\n") {
+		b.WriteString(editorHeaderPrefix + line + "\n")
+	}
+	b.WriteString(text)
+	return b.String()
+}
+
+// FormatApplyError turns API errors into a readable, possibly multi-line message
+func FormatApplyError(err error) string {
+	var statusErr *apierrors.StatusError
+	switch {
+	case apierrors.IsConflict(err):
+		return "Conflict: the resource was modified in the cluster since you started editing. " +
+			"Your changes were moved onto the current version, reopen the editor to review them and save again."
+	case apierrors.IsInvalid(err) && errors.As(err, &statusErr):
+		details := statusErr.ErrStatus.Details
+		if details == nil || len(details.Causes) == 0 {
+			return "Validation failed: " + statusErr.ErrStatus.Message
+		}
+		lines := []string{"Validation failed:"}
+		for _, cause := range details.Causes {
+			if cause.Field != "" {
+				lines = append(lines, fmt.Sprintf("  %s: %s", cause.Field, cause.Message))
+			} else {
+				lines = append(lines, "  "+cause.Message)
+			}
+		}
+		return strings.Join(lines, "\n")
+	default:
+		return err.Error()
+	}
+}
+
+// editorCommand returns the command to edit path with, honoring $KUBE_EDITOR and $EDITOR
+func editorCommand(path string) *exec.Cmd {
+	// Blank variables count as unset
+	editor := strings.TrimSpace(os.Getenv("KUBE_EDITOR"))
+	if editor == "" {
+		editor = strings.TrimSpace(os.Getenv("EDITOR"))
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// Allow editors with arguments such as "code --wait"
+	parts := strings.Fields(editor)
+	return exec.Command(parts[0], append(parts[1:], path)...)
+}
+
+// openEditor writes text to a temp file and suspends the TUI while the editor runs
+func openEditor(name, text string) tea.Cmd {
+	f, err := os.CreateTemp("", "crdlens-"+name+"-*.yaml")
+	if err != nil {
+		return func() tea.Msg { return EditorFinishedMsg{Err: err} }
+	}
+	path := f.Name()
+
+	if _, err := f.WriteString(text); err != nil {
+		f.Close()
+		os.Remove(path)
+		return func() tea.Msg { return EditorFinishedMsg{Err: err} }
+	}
+	f.Close()
+
+	return tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		return EditorFinishedMsg{Path: path, Err: err}
+	})
+}
+
+// readEditedFile returns the edited text without the crdlens header and removes the file
+func readEditedFile(path string) (string, error) {
+	defer os.Remove(path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return stripEditorHeader(string(data)), nil
+}
@@ -0,0 +1,126 @@
+package views
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/pteich/crdlens/internal/types"
+)
+
+func testEditObject() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"metadata": map[string]interface{}{
+			"name":            "foo",
+			"namespace":       "default",
+			"resourceVersion": "7",
+			"managedFields":   []interface{}{map[string]interface{}{"manager": "kubectl"}},
+		},
+		"spec":   map[string]interface{}{"replicas": int64(1)},
+		"status": map[string]interface{}{"ready": true},
+	}}
+}
+
+func TestEditableYAML(t *testing.T) {
+	text, err := EditableYAML(testEditObject())
+	require.NoError(t, err)
+
+	assert.Contains(t, text, "replicas: 1")
+	assert.Contains(t, text, "resourceVersion: \"7\"")
+	assert.NotContains(t, text, "managedFields")
+	assert.NotContains(t, text, "status")
+}
+
+func TestParseEditedYAML(t *testing.T) {
+	original := testEditObject()
+	text, err := EditableYAML(original)
+	require.NoError(t, err)
+
+	obj, err := ParseEditedYAML(text, original)
+	require.NoError(t, err)
+	ready, found, _ := unstructured.NestedBool(obj.Object, "status", "ready")
+	assert.True(t, found, "status should be carried over from the original")
+	assert.True(t, ready)
+
+	_, err = ParseEditedYAML("spec: [", original)
+	assert.Error(t, err)
+
+	_, err = ParseEditedYAML("metadata:\n  name: other\n  namespace: default\n", original)
+	assert.Error(t, err, "renaming is not allowed")
+}
+
+func TestEditorHeader(t *testing.T) {
+	text := "spec:\n  replicas: 2\n"
+	withHeader := withEditorHeader(text, errors.New("boom"))
+
+	assert.Contains(t, withHeader, editorHeaderPrefix+"boom")
+	assert.Equal(t, text, stripEditorHeader(withHeader))
+	assert.Equal(t, text, withEditorHeader(text, nil))
+}
+
+func TestFormatApplyError(t *testing.T) {
+	gr := schema.GroupResource{Group: "example.com", Resource: "widgets"}
+
+	conflict := apierrors.NewConflict(gr, "foo", errors.New("modified"))
+	assert.Contains(t, FormatApplyError(conflict), "Conflict")
+
+	invalid := apierrors.NewInvalid(schema.GroupKind{Group: "example.com", Kind: "Widget"}, "foo", field.ErrorList{
+		field.Invalid(field.NewPath("spec", "replicas"), -1, "must be positive"),
+	})
+	msg := FormatApplyError(invalid)
+	assert.Contains(t, msg, "Validation failed")
+	assert.Contains(t, msg, "spec.replicas")
+}
+
+func TestRebaseEditedYAML(t *testing.T) {
+	original := testEditObject()
+	text, err := EditableYAML(original)
+	require.NoError(t, err)
+	text = strings.Replace(text, "replicas: 1", "replicas: 3", 1)
+
+	rebased, err := RebaseEditedYAML(text, "9")
+	require.NoError(t, err)
+
+	obj, err := ParseEditedYAML(rebased, original)
+	require.NoError(t, err)
+	assert.Equal(t, "9", obj.GetResourceVersion())
+	assert.Contains(t, rebased, "replicas: 3", "edits should be kept")
+
+	_, err = RebaseEditedYAML("spec: [", "9")
+	assert.Error(t, err)
+}
+
+func TestCRDetailModel_EditorFailedRemovesFile(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "crdlens-*.yaml")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	m := NewCRDetailModel(nil, nil, types.Resource{}, 100, 100)
+	m.Update(EditorFinishedMsg{Path: f.Name(), Err: errors.New("exit status 1")})
+
+	assert.Error(t, m.editErr)
+	_, err = os.Stat(f.Name())
+	assert.True(t, os.IsNotExist(err), "temp file should be removed")
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("KUBE_EDITOR", "code --wait")
+	t.Setenv("EDITOR", "nano")
+	assert.Equal(t, []string{"code", "--wait", "/tmp/x.yaml"}, editorCommand("/tmp/x.yaml").Args)
+
+	t.Setenv("KUBE_EDITOR", "  ")
+	assert.Equal(t, []string{"nano", "/tmp/x.yaml"}, editorCommand("/tmp/x.yaml").Args)
+
+	t.Setenv("EDITOR", "\t")
+	assert.Equal(t, []string{"vi", "/tmp/x.yaml"}, editorCommand("/tmp/x.yaml").Args)
+}
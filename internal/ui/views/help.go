@@ -22,6 +22,21 @@ type keyMap struct {
 	Refresh   key.Binding
 	ViewSpec  key.Binding
 	TabView   key.Binding
+	Favorite  key.Binding
+	Favorites key.Binding
+	Selector  key.Binding
+	Columns   key.Binding
+	Version   key.Binding
+	Context   key.Binding
+	Report    key.Binding
+
+	// Actions on resources and CRDs
+	Edit       key.Binding
+	Delete     key.Binding
+	Finalizers key.Binding
+	Reconcile  key.Binding
+	Suspend    key.Binding
+	Sample     key.Binding
 }
 
 // ShortHelp returns keybindings to be shown in the mini help view
@@ -32,9 +47,10 @@ func (k keyMap) ShortHelp() []key.Binding {
 // FullHelp returns keybindings to be shown in the expanded help view
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.Left, k.Right},                         // first column
-		{k.Enter, k.Esc, k.Filter},                              // second column
-		{k.Namespace, k.Refresh, k.ViewSpec, k.TabView, k.Quit}, // third column
+		{k.Up, k.Down, k.Left, k.Right, k.Enter, k.Esc, k.TabView},                        // navigation
+		{k.Filter, k.Selector, k.Columns, k.ViewSpec, k.Version, k.Favorite, k.Favorites}, // lists
+		{k.Namespace, k.Context, k.Refresh, k.Report, k.Help, k.Quit},                     // global
+		{k.Edit, k.Delete, k.Finalizers, k.Reconcile, k.Suspend, k.Sample},                // actions
 	}
 }
 
@@ -52,8 +68,8 @@ var keys = keyMap{
 		key.WithHelp("←/h", "move left"),
 	),
 	Right: key.NewBinding(
-		key.WithKeys("right", "l"),
-		key.WithHelp("→/l", "move right"),
+		key.WithKeys("right"),
+		key.WithHelp("→", "move right"),
 	),
 	Enter: key.NewBinding(
 		key.WithKeys("enter"),
@@ -85,12 +101,64 @@ var keys = keyMap{
 	),
 	ViewSpec: key.NewBinding(
 		key.WithKeys("s"),
-		key.WithHelp("s", "view CRD spec"),
+		key.WithHelp("s", "view CRD spec / sort CRs"),
 	),
 	TabView: key.NewBinding(
 		key.WithKeys("tab"),
 		key.WithHelp("tab", "toggle view (YAML/Table)"),
 	),
+	Favorite: key.NewBinding(
+		key.WithKeys("*"),
+		key.WithHelp("*", "star/unstar"),
+	),
+	Favorites: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "favorites only"),
+	),
+	Selector: key.NewBinding(
+		key.WithKeys("l", "f"),
+		key.WithHelp("l/f", "label/field selector"),
+	),
+	Columns: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "toggle printer columns"),
+	),
+	Version: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "pick CRD version"),
+	),
+	Context: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "switch context"),
+	),
+	Report: key.NewBinding(
+		key.WithKeys("u"),
+		key.WithHelp("u", "unhealthy resources"),
+	),
+	Edit: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "edit resource"),
+	),
+	Delete: key.NewBinding(
+		key.WithKeys("d"),
+		key.WithHelp("d", "delete / diff schema"),
+	),
+	Finalizers: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "remove finalizers"),
+	),
+	Reconcile: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "request reconcile"),
+	),
+	Suspend: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "suspend/resume"),
+	),
+	Sample: key.NewBinding(
+		key.WithKeys("g"),
+		key.WithHelp("g", "generate sample"),
+	),
 }
 
 // HelpModel is the model for the help view
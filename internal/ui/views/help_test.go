@@ -0,0 +1,19 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHelpModel_View(t *testing.T) {
+	view := NewHelpModel().View()
+
+	for _, binding := range []string{
+		"label/field selector", "toggle printer columns", "pick CRD version", "switch context",
+		"unhealthy resources", "edit resource", "request reconcile", "suspend/resume", "remove finalizers",
+	} {
+		assert.Contains(t, view, binding)
+	}
+	assert.NotContains(t, view, "→/l", "l opens the label selector")
+}
@@ -0,0 +1,81 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/pteich/crdlens/internal/k8s"
+)
+
+// newManagedFieldsTable creates the table that lists the managedFields entries and their fields
+func newManagedFieldsTable(width, height int) table.Model {
+	nameWidth := width - 60
+	if nameWidth < 40 {
+		nameWidth = 40
+	}
+	t := table.New(
+		table.WithColumns([]table.Column{
+			{Title: "Manager / Field", Width: nameWidth},
+			{Title: "Operation", Width: 10},
+			{Title: "Subresource", Width: 12},
+			{Title: "Updated", Width: 10},
+			{Title: "API Version", Width: 20},
+		}),
+		table.WithFocused(true),
+		table.WithHeight(height-10),
+	)
+	s := table.DefaultStyles()
+	s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true).Bold(false)
+	s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Bold(false)
+	t.SetStyles(s)
+	return t
+}
+
+// managedFieldsRows lists each managedFields entry followed by the fields it owns
+func managedFieldsRows(owners []k8s.FieldOwnership, now time.Time) []table.Row {
+	var rows []table.Row
+	for _, o := range owners {
+		subresource := o.Subresource
+		if subresource == "" {
+			subresource = "-"
+		}
+		updated := "-"
+		if !o.Time.IsZero() {
+			updated = duration.HumanDuration(now.Sub(o.Time))
+		}
+		rows = append(rows, table.Row{
+			fmt.Sprintf("%s (%d fields)", o.Manager, len(o.Paths)),
+			o.Operation,
+			subresource,
+			updated,
+			o.APIVersion,
+		})
+		for _, p := range o.Paths {
+			rows = append(rows, table.Row{"   " + p, "", "", "", ""})
+		}
+	}
+	return rows
+}
+
+// fieldManagers returns the distinct managers of a field, a warning sign marks
+// fields that are owned by more than one manager
+func fieldManagers(owners map[string][]string, key string) string {
+	var managers []string
+	seen := make(map[string]bool)
+	for _, m := range owners[key] {
+		if !seen[m] {
+			seen[m] = true
+			managers = append(managers, m)
+		}
+	}
+	text := strings.Join(managers, ", ")
+	if len(managers) > 1 {
+		text = "⚠ " + text
+	}
+	return text
+}
@@ -0,0 +1,100 @@
+package views
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/pteich/crdlens/internal/k8s"
+	"github.com/pteich/crdlens/internal/types"
+)
+
+// ownerTreeLine is a node of the owner tree together with the prefix that draws its branch
+type ownerTreeLine struct {
+	node   *k8s.OwnerTreeNode
+	prefix string
+}
+
+// FetchedOwnerTreeMsg is sent when the owner tree of a resource was built
+type FetchedOwnerTreeMsg struct {
+	UID  string
+	Tree *k8s.OwnerTree
+	Err  error
+}
+
+// OpenResourceMsg asks to open the detail view of another resource
+type OpenResourceMsg struct {
+	Resource types.Resource
+}
+
+// fetchOwnerTree is a command that builds the owner tree of a resource
+func fetchOwnerTree(client *k8s.Client, res types.Resource) tea.Cmd {
+	return func() tea.Msg {
+		tree, err := client.Owners().BuildTree(context.Background(), res)
+		return FetchedOwnerTreeMsg{UID: res.UID, Tree: tree, Err: err}
+	}
+}
+
+// newOwnerTreeTable creates the table that shows the flattened owner tree
+func newOwnerTreeTable(width, height int) table.Model {
+	nameWidth := width - 50
+	if nameWidth < 40 {
+		nameWidth = 40
+	}
+	t := table.New(
+		table.WithColumns([]table.Column{
+			{Title: "Resource", Width: nameWidth},
+			{Title: "Namespace", Width: 20},
+			{Title: "Status", Width: 12},
+			{Title: "Age", Width: 8},
+		}),
+		table.WithFocused(true),
+		table.WithHeight(height-10),
+	)
+	s := table.DefaultStyles()
+	s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true).Bold(false)
+	s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Bold(false)
+	t.SetStyles(s)
+	return t
+}
+
+// flattenOwnerTree lists the nodes depth-first with the branches drawn like tree(1)
+func flattenOwnerTree(root *k8s.OwnerTreeNode) []ownerTreeLine {
+	lines := []ownerTreeLine{{node: root}}
+
+	var walk func(n *k8s.OwnerTreeNode, indent string)
+	walk = func(n *k8s.OwnerTreeNode, indent string) {
+		for i, c := range n.Children {
+			branch, next := "├─ ", "│  "
+			if i == len(n.Children)-1 {
+				branch, next = "└─ ", "   "
+			}
+			lines = append(lines, ownerTreeLine{node: c, prefix: indent + branch})
+			walk(c, indent+next)
+		}
+	}
+	walk(root, "")
+	return lines
+}
+
+// ownerTreeRow renders a line of the owner tree, the resource of the detail view is marked
+func ownerTreeRow(line ownerTreeLine, currentUID string, now time.Time) table.Row {
+	res := line.node.Resource
+	if line.node.Missing {
+		return table.Row{line.prefix + "⚠ " + res.Kind + "/" + res.Name + " (not found)", res.Namespace, "Missing", "-"}
+	}
+
+	name := line.prefix + res.ReadyIcon() + " " + res.Kind + "/" + res.Name
+	if res.UID == currentUID {
+		name += " ◀"
+	}
+	age := "-"
+	if !res.CreatedAt.IsZero() {
+		age = duration.HumanDuration(now.Sub(res.CreatedAt))
+	}
+	return table.Row{name, res.Namespace, res.ReadyStatus(), age}
+}
@@ -0,0 +1,211 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/pteich/crdlens/internal/k8s"
+	"github.com/pteich/crdlens/internal/types"
+)
+
+// FetchedReportMsg is sent when a scan for unhealthy resources finished
+type FetchedReportMsg struct {
+	Namespace string
+	Report    *k8s.Report
+	Err       error
+}
+
+// ReportModel is the dashboard of unhealthy resources across all CRDs
+type ReportModel struct {
+	client    *k8s.Client
+	namespace string // Empty scans all namespaces
+	table     table.Model
+	spinner   spinner.Model
+	loading   bool
+	err       error
+	report    *k8s.Report
+	rows      []*k8s.UnhealthyResource // Resource of each table row, nil for group headers
+	width     int
+	height    int
+}
+
+// NewReportModel creates the dashboard for the given namespace, empty for all namespaces
+func NewReportModel(client *k8s.Client, namespace string, width, height int) *ReportModel {
+	nameWidth := width - 90
+	if nameWidth < 40 {
+		nameWidth = 40
+	}
+	t := table.New(
+		table.WithColumns([]table.Column{
+			{Title: "CRD / Resource", Width: nameWidth},
+			{Title: "Namespace", Width: 20},
+			{Title: "Controller", Width: 24},
+			{Title: "Status", Width: 12},
+			{Title: "Reasons", Width: 30},
+		}),
+		table.WithFocused(true),
+		table.WithHeight(height-10),
+	)
+	s := table.DefaultStyles()
+	s.Header = s.Header.BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).BorderBottom(true).Bold(false)
+	s.Selected = s.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Bold(false)
+	t.SetStyles(s)
+
+	spn := spinner.New()
+	spn.Spinner = spinner.Dot
+	spn.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#F780E2"))
+
+	return &ReportModel{
+		client:    client,
+		namespace: namespace,
+		table:     t,
+		spinner:   spn,
+		loading:   true,
+		width:     width,
+		height:    height,
+	}
+}
+
+// Init starts the scan
+func (m *ReportModel) Init() tea.Cmd {
+	return tea.Batch(m.fetchReport(), m.spinner.Tick)
+}
+
+// Refresh scans again, namespace is empty for all namespaces
+func (m *ReportModel) Refresh(namespace string) tea.Cmd {
+	m.namespace = namespace
+	m.loading = true
+	return tea.Batch(m.fetchReport(), m.spinner.Tick)
+}
+
+// fetchReport is a command that scans all CRDs for unhealthy resources
+func (m *ReportModel) fetchReport() tea.Cmd {
+	if m.client == nil {
+		return nil
+	}
+	client, namespace := m.client, m.namespace
+	return func() tea.Msg {
+		report, err := client.Report().Scan(context.Background(), k8s.ReportOptions{
+			Namespace:    namespace,
+			SilenceAfter: k8s.DefaultReportSilence,
+		})
+		return FetchedReportMsg{Namespace: namespace, Report: report, Err: err}
+	}
+}
+
+// Update handles messages
+func (m *ReportModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case FetchedReportMsg:
+		if msg.Namespace != m.namespace {
+			return m, nil
+		}
+		m.loading = false
+		m.err = msg.Err
+		if msg.Err == nil {
+			m.setReport(msg.Report)
+		}
+		return m, nil
+
+	case spinner.TickMsg:
+		if !m.loading {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.table.SetHeight(m.height - 10)
+		return m, nil
+
+	case tea.KeyMsg:
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// setReport flattens the groups of the report into table rows, a header row per group
+func (m *ReportModel) setReport(report *k8s.Report) {
+	m.report = report
+	m.rows = nil
+
+	var rows []table.Row
+	for _, g := range report.Groups() {
+		rows = append(rows, table.Row{
+			fmt.Sprintf("▾ %s (%d)", g.CRD, len(g.Resources)), g.Namespace, g.Controller, "", "",
+		})
+		m.rows = append(m.rows, nil)
+		for i := range g.Resources {
+			u := &g.Resources[i]
+			rows = append(rows, table.Row{
+				"   " + u.Resource.ReadyIcon() + " " + u.Resource.Name,
+				u.Resource.Namespace,
+				u.Resource.ControllerManager,
+				u.Resource.ReadyStatus(),
+				strings.Join(u.Reasons, ", "),
+			})
+			m.rows = append(m.rows, u)
+		}
+	}
+	m.table.SetRows(rows)
+	m.table.SetCursor(0)
+}
+
+// SelectedResource returns the resource in the selected row, or an empty resource for group headers
+func (m *ReportModel) SelectedResource() types.Resource {
+	idx := m.table.Cursor()
+	if idx >= 0 && idx < len(m.rows) && m.rows[idx] != nil {
+		return m.rows[idx].Resource
+	}
+	return types.Resource{}
+}
+
+// View renders the dashboard
+func (m *ReportModel) View() string {
+	scope := "all namespaces"
+	if m.namespace != "" {
+		scope = "namespace " + m.namespace
+	}
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4")).Padding(0, 1)
+
+	if m.loading {
+		title := titleStyle.Render("Unhealthy Resources")
+		return lipgloss.JoinVertical(lipgloss.Left, title, "\n",
+			fmt.Sprintf(" %s Scanning all CRDs in %s...", m.spinner.View(), scope))
+	}
+	if m.err != nil {
+		return fmt.Sprintf("Error scanning CRDs: %v", m.err)
+	}
+
+	title := titleStyle.Render(fmt.Sprintf("Unhealthy Resources (%d)", len(m.report.Resources)))
+	summary := fmt.Sprintf(" Scanned %d resources of %d CRDs in %s", m.report.ScannedResources, m.report.ScannedCRDs, scope)
+	if n := len(m.report.Failed); n > 0 {
+		summary += lipgloss.NewStyle().Foreground(lipgloss.Color("3")).
+			Render(fmt.Sprintf(", %d CRD%s could not be listed", n, pluralize(n)))
+	}
+
+	body := m.table.View()
+	if len(m.report.Resources) == 0 {
+		body = lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Render(" ✓ No unhealthy resources found")
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		summary,
+		"\n",
+		body,
+		"\n",
+		lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(" [Enter] Open resource  [r] Scan again  [Esc] Back"),
+	)
+}
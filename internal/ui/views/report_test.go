@@ -0,0 +1,54 @@
+package views
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pteich/crdlens/internal/k8s"
+	"github.com/pteich/crdlens/internal/types"
+)
+
+func TestReportModel(t *testing.T) {
+	m := NewReportModel(nil, "", 120, 40)
+	assert.Contains(t, m.View(), "Scanning all CRDs in all namespaces")
+
+	report := &k8s.Report{
+		ScannedCRDs:      2,
+		ScannedResources: 10,
+		Failed:           map[string]string{"secrets.example.com": "forbidden"},
+		Resources: []k8s.UnhealthyResource{
+			{CRD: "apps.example.com", Resource: types.Resource{Name: "web", Namespace: "prod", ControllerManager: "app-controller"}, Reasons: []string{"drift 1"}},
+			{CRD: "apps.example.com", Resource: types.Resource{Name: "api", Namespace: "prod", ControllerManager: "app-controller"}, Reasons: []string{"NotReady"}},
+			{CRD: "widgets.example.com", Resource: types.Resource{Name: "w", Namespace: "dev"}, Reasons: []string{"Progressing"}},
+		},
+	}
+
+	// Results of a scan for another namespace are ignored
+	m.Update(FetchedReportMsg{Namespace: "other", Report: report})
+	assert.True(t, m.loading)
+
+	m.Update(FetchedReportMsg{Report: report})
+	assert.False(t, m.loading)
+
+	// Two groups with a header row each
+	assert.Len(t, m.table.Rows(), 5)
+	assert.Equal(t, "▾ apps.example.com (2)", m.table.Rows()[0][0])
+	assert.Empty(t, m.SelectedResource().Name, "group headers are not resources")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, "web", m.SelectedResource().Name)
+
+	view := m.View()
+	assert.Contains(t, view, "Unhealthy Resources (3)")
+	assert.Contains(t, view, "Scanned 10 resources of 2 CRDs in all namespaces")
+	assert.Contains(t, view, "1 CRD could not be listed")
+
+	m.Update(FetchedReportMsg{Report: &k8s.Report{}})
+	assert.Contains(t, m.View(), "No unhealthy resources found")
+
+	m.Update(FetchedReportMsg{Err: errors.New("forbidden")})
+	assert.Contains(t, m.View(), "Error scanning CRDs: forbidden")
+}
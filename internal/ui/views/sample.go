@@ -0,0 +1,325 @@
+package views
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// sampleCommentWidth is the maximum width of description comments in generated samples
+const sampleCommentWidth = 80
+
+// GenerateSample renders an example manifest for a CRD version from its schema.
+// A minimal sample only contains required fields, a full sample contains all of them.
+// An empty version selects the storage version.
+func GenerateSample(crd *apiextensionsv1.CustomResourceDefinition, version string, full bool) (string, error) {
+	if crd == nil {
+		return "", fmt.Errorf("no CRD given")
+	}
+	if version == "" {
+		version = StorageVersion(crd)
+	}
+
+	served := false
+	for _, v := range crd.Spec.Versions {
+		if v.Name == version && v.Served {
+			served = true
+		}
+	}
+	if !served {
+		return "", fmt.Errorf("version %s of %s is not served", version, crd.Name)
+	}
+	// Versions without a schema or without properties result in an empty sample
+	fields := ExtractCRDSchemaFieldsForVersion(crd, version)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "apiVersion: %s/%s\n", crd.Spec.Group, version)
+	fmt.Fprintf(&b, "kind: %s\n", crd.Spec.Names.Kind)
+	b.WriteString("metadata:\n")
+	fmt.Fprintf(&b, "  name: example-%s\n", strings.ToLower(crd.Spec.Names.Kind))
+	if crd.Spec.Scope == apiextensionsv1.NamespaceScoped {
+		b.WriteString("  namespace: default\n")
+	}
+
+	for _, f := range fields {
+		switch f.Name {
+		case "apiVersion", "kind", "metadata", "status":
+			// Written above or owned by the controller
+			continue
+		case "spec":
+			// Always include the spec, even if the schema doesn't require it
+			f.Required = true
+		}
+		writeSampleField(&b, f, "", full)
+	}
+
+	return b.String(), nil
+}
+
+// StorageVersion returns the served storage version of a CRD, or the first served version
+func StorageVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	version := ""
+	for _, v := range crd.Spec.Versions {
+		if !v.Served {
+			continue
+		}
+		if v.Storage {
+			return v.Name
+		}
+		if version == "" {
+			version = v.Name
+		}
+	}
+	return version
+}
+
+// writeSampleField writes a field with its comments, nested fields are written recursively
+func writeSampleField(b *strings.Builder, f SchemaField, indent string, full bool) {
+	if !full && !f.Required {
+		return
+	}
+
+	writeSampleComments(b, f, indent)
+
+	children := sampleChildren(f)
+	if len(children) == 0 {
+		fmt.Fprintf(b, "%s%s: %s\n", indent, f.Name, samplePlaceholder(f))
+		return
+	}
+
+	var nested strings.Builder
+	for _, child := range children {
+		writeSampleField(&nested, child, indent+"  ", full)
+	}
+	if nested.Len() == 0 {
+		// No required nested fields in a minimal sample
+		fmt.Fprintf(b, "%s%s: %s\n", indent, f.Name, samplePlaceholder(SchemaField{Type: f.Type, MinItems: f.MinItems}))
+		return
+	}
+
+	fmt.Fprintf(b, "%s%s:\n", indent, f.Name)
+	switch {
+	case isListType(f.Type):
+		// Turn the nested block into a single list item
+		lines := strings.Split(strings.TrimSuffix(nested.String(), "\n"), "\n")
+		for i, line := range lines {
+			line = strings.TrimPrefix(line, indent+"  ")
+			prefix := indent + "  "
+			if i == 0 {
+				prefix = indent + "- "
+			}
+			b.WriteString(prefix + line + "\n")
+		}
+	case strings.HasPrefix(f.Type, "map["):
+		fmt.Fprintf(b, "%s  key:\n", indent)
+		for _, line := range strings.Split(strings.TrimSuffix(nested.String(), "\n"), "\n") {
+			b.WriteString("  " + line + "\n")
+		}
+	default:
+		b.WriteString(nested.String())
+	}
+}
+
+// sampleChildren returns the nested fields to write. For alternatives only the first one is used.
+func sampleChildren(f SchemaField) []SchemaField {
+	var props, alternatives []SchemaField
+	for _, child := range f.Children {
+		if isAlternative(child) {
+			alternatives = append(alternatives, child)
+		} else {
+			props = append(props, child)
+		}
+	}
+	if len(props) == 0 && len(alternatives) > 0 {
+		return alternatives[0].Children
+	}
+	return props
+}
+
+// writeSampleComments writes the description and allowed values as YAML comments
+func writeSampleComments(b *strings.Builder, f SchemaField, indent string) {
+	for _, line := range wrapText(firstParagraph(f.Description), sampleCommentWidth-len(indent)-2) {
+		fmt.Fprintf(b, "%s# %s\n", indent, line)
+	}
+	if len(f.Enum) > 1 {
+		fmt.Fprintf(b, "%s# Allowed values: %s\n", indent, strings.Join(f.Enum, ", "))
+	}
+	if hint := sampleHint(f); hint != "" {
+		fmt.Fprintf(b, "%s# %s\n", indent, hint)
+	}
+}
+
+// sampleHint returns a note for constraints the generated value can't satisfy, so the
+// value has to be filled in before the sample is valid
+func sampleHint(f SchemaField) string {
+	if f.Default != "" || len(f.Enum) > 0 {
+		return ""
+	}
+	if f.Type == "string" && f.Pattern != "" {
+		if _, ok := sampleString(f); !ok {
+			return "Replace with a value matching " + f.Pattern
+		}
+	}
+	if isListType(f.Type) && f.MinItems != nil && *f.MinItems > 1 {
+		return fmt.Sprintf("At least %d items are required", *f.MinItems)
+	}
+	return ""
+}
+
+// samplePlaceholder returns the default, the first enum value or a value matching the type
+// and its lower bounds
+func samplePlaceholder(f SchemaField) string {
+	switch {
+	case f.Default != "":
+		return f.Default
+	case len(f.Enum) > 0:
+		return f.Enum[0]
+	}
+
+	switch {
+	case f.Type == "string":
+		value, _ := sampleString(f)
+		return value
+	case f.Type == "integer", f.Type == "number", f.Type == "int-or-string":
+		return sampleNumber(f)
+	case f.Type == "boolean":
+		return "false"
+	case isListType(f.Type):
+		return sampleList(f)
+	case strings.HasPrefix(f.Type, "map["), strings.HasPrefix(f.Type, "object"),
+		f.Type == "embedded resource", f.Type == "any":
+		return "{}"
+	default:
+		return "null"
+	}
+}
+
+// sampleFormats are valid values for the string formats the API server checks
+var sampleFormats = map[string]string{
+	"date":      "1970-01-01",
+	"date-time": "1970-01-01T00:00:00Z",
+	"duration":  "1s",
+	"email":     "user@example.com",
+	"hostname":  "example.com",
+	"ipv4":      "127.0.0.1",
+	"ipv6":      "::1",
+	"cidr":      "10.0.0.0/8",
+	"mac":       "00:00:00:00:00:00",
+	"uri":       "https://example.com",
+	"uuid":      "00000000-0000-0000-0000-000000000000",
+}
+
+// sampleString returns a quoted string of the field's format and minimum length. If the
+// field has a pattern, a few candidates are tried, false means none of them matched.
+func sampleString(f SchemaField) (string, bool) {
+	candidates := []string{sampleFormats[f.Format]}
+	if f.Pattern != "" && f.Format == "" {
+		candidates = append(candidates, "example", "a", "0", "example-1")
+	}
+
+	var pattern *regexp.Regexp
+	if f.Pattern != "" {
+		var err error
+		if pattern, err = regexp.Compile(f.Pattern); err != nil {
+			return fmt.Sprintf("%q", padSample(candidates[0], f.MinLength)), false
+		}
+	}
+	for _, c := range candidates {
+		c = padSample(c, f.MinLength)
+		if pattern == nil || pattern.MatchString(c) {
+			return fmt.Sprintf("%q", c), true
+		}
+	}
+	return fmt.Sprintf("%q", padSample(candidates[0], f.MinLength)), false
+}
+
+// padSample repeats the last character of s, or "a" for an empty s, up to minLength
+func padSample(s string, minLength *int64) string {
+	if minLength == nil || int64(len(s)) >= *minLength {
+		return s
+	}
+	pad := "a"
+	if s != "" {
+		pad = s[len(s)-1:]
+	}
+	return s + strings.Repeat(pad, int(*minLength)-len(s))
+}
+
+// sampleNumber returns 0, or the minimum if 0 is below it
+func sampleNumber(f SchemaField) string {
+	if f.Minimum == nil {
+		return "0"
+	}
+	minimum := *f.Minimum
+	if minimum < 0 || (minimum == 0 && !f.ExclusiveMinimum) {
+		return "0"
+	}
+
+	value := minimum
+	if f.Type != "number" {
+		value = math.Ceil(value)
+	}
+	if f.ExclusiveMinimum && value == minimum {
+		value++
+	}
+	return formatNumber(value)
+}
+
+// sampleList returns an empty list, or minItems placeholders of the item type
+func sampleList(f SchemaField) string {
+	if f.MinItems == nil || *f.MinItems <= 0 {
+		return "[]"
+	}
+
+	itemType := "object"
+	if i := strings.Index(f.Type, " of "); i >= 0 {
+		itemType = f.Type[i+len(" of "):]
+	}
+	items := make([]string, *f.MinItems)
+	for i := range items {
+		items[i] = samplePlaceholder(SchemaField{Type: itemType})
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
+
+func isListType(t string) bool {
+	return strings.HasPrefix(t, "array") || strings.HasPrefix(t, "list-map") || strings.HasPrefix(t, "set of")
+}
+
+func isAlternative(f SchemaField) bool {
+	return strings.HasPrefix(f.Name, "anyOf[") || strings.HasPrefix(f.Name, "oneOf[") || strings.HasPrefix(f.Name, "allOf[")
+}
+
+// firstParagraph returns the description up to the first blank line
+func firstParagraph(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, "\n\n"); i >= 0 {
+		s = s[:i]
+	}
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// wrapText splits text into lines of at most width characters at word boundaries
+func wrapText(s string, width int) []string {
+	if s == "" {
+		return nil
+	}
+
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = word
+			continue
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	return append(lines, line)
+}
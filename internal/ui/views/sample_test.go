@@ -0,0 +1,157 @@
+package views
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pteich/crdlens/internal/k8s"
+)
+
+func sampleTestCRD() *apiextensionsv1.CustomResourceDefinition {
+	listMap := "map"
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "Widget", Plural: "widgets"},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:    "v1",
+				Served:  true,
+				Storage: true,
+				Schema: &apiextensionsv1.CustomResourceValidation{
+					OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"spec": {
+								Type:     "object",
+								Required: []string{"host", "ports", "mode"},
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"host": {Type: "string", Description: "Host name the widget listens on."},
+									"mode": {
+										Type: "string",
+										Enum: []apiextensionsv1.JSON{{Raw: []byte(`"Auto"`)}, {Raw: []byte(`"Manual"`)}},
+									},
+									"replicas": {Type: "integer", Default: &apiextensionsv1.JSON{Raw: []byte(`3`)}},
+									"ports": {
+										Type:         "array",
+										XListType:    &listMap,
+										XListMapKeys: []string{"name"},
+										Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+											Schema: &apiextensionsv1.JSONSchemaProps{
+												Type:     "object",
+												Required: []string{"name", "port"},
+												Properties: map[string]apiextensionsv1.JSONSchemaProps{
+													"name":     {Type: "string", Description: "Name of the port."},
+													"port":     {Type: "integer"},
+													"protocol": {Type: "string"},
+												},
+											},
+										},
+									},
+									"labels": {
+										Type: "object",
+										AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{
+											Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"},
+										},
+									},
+								},
+							},
+							"status": {Type: "object"},
+						},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func TestGenerateSample_Minimal(t *testing.T) {
+	sample, err := GenerateSample(sampleTestCRD(), "", false)
+	require.NoError(t, err)
+
+	assert.Contains(t, sample, "apiVersion: example.com/v1\nkind: Widget\n")
+	assert.Contains(t, sample, "# Host name the widget listens on.\n  host: \"\"\n")
+	assert.Contains(t, sample, "# Allowed values: \"Auto\", \"Manual\"\n  mode: \"Auto\"\n")
+	assert.NotContains(t, sample, "replicas")
+	assert.NotContains(t, sample, "protocol")
+	assert.NotContains(t, sample, "status")
+
+	var obj map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(sample), &obj), sample)
+	spec := obj["spec"].(map[string]interface{})
+	ports := spec["ports"].([]interface{})
+	require.Len(t, ports, 1)
+	assert.Equal(t, map[string]interface{}{"name": "", "port": float64(0)}, ports[0])
+}
+
+func TestGenerateSample_Full(t *testing.T) {
+	sample, err := GenerateSample(sampleTestCRD(), "v1", true)
+	require.NoError(t, err)
+
+	var obj map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(sample), &obj), sample)
+	spec := obj["spec"].(map[string]interface{})
+	assert.Equal(t, float64(3), spec["replicas"], "defaults are used as values")
+	assert.Equal(t, map[string]interface{}{}, spec["labels"])
+	assert.Contains(t, spec["ports"].([]interface{})[0], "protocol")
+
+	_, err = GenerateSample(sampleTestCRD(), "v2", true)
+	assert.Error(t, err)
+}
+
+func TestGenerateSample_Constraints(t *testing.T) {
+	crd := sampleTestCRD()
+	spec := crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"]
+	spec.Required = append(spec.Required, "slug", "since", "size", "ratio", "tags", "rules")
+	spec.Properties["slug"] = apiextensionsv1.JSONSchemaProps{Type: "string", Pattern: "^[a-z0-9-]+$", MinLength: ptr.To[int64](3)}
+	spec.Properties["since"] = apiextensionsv1.JSONSchemaProps{Type: "string", Format: "date-time"}
+	spec.Properties["size"] = apiextensionsv1.JSONSchemaProps{Type: "integer", Minimum: ptr.To(1.5)}
+	spec.Properties["ratio"] = apiextensionsv1.JSONSchemaProps{Type: "number", Minimum: ptr.To(0.0), ExclusiveMinimum: true}
+	spec.Properties["tags"] = apiextensionsv1.JSONSchemaProps{
+		Type:     "array",
+		MinItems: ptr.To[int64](2),
+		Items:    &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"}},
+	}
+	spec.Properties["rules"] = apiextensionsv1.JSONSchemaProps{
+		Type:     "array",
+		MinItems: ptr.To[int64](1),
+		Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{
+			Type:       "object",
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{"path": {Type: "string"}},
+		}},
+	}
+	crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"] = spec
+
+	validator := k8s.NewSchemaValidator([]*apiextensionsv1.CustomResourceDefinition{crd})
+	for _, full := range []bool{false, true} {
+		sample, err := GenerateSample(crd, "", full)
+		require.NoError(t, err)
+
+		obj := &unstructured.Unstructured{}
+		require.NoError(t, yaml.Unmarshal([]byte(sample), &obj.Object), sample)
+		violations, err := validator.Validate(context.Background(), obj)
+		require.NoError(t, err)
+		assert.Empty(t, violations, sample)
+	}
+}
+
+func TestGenerateSample_PatternHint(t *testing.T) {
+	crd := sampleTestCRD()
+	spec := crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"]
+	spec.Required = append(spec.Required, "code")
+	spec.Properties["code"] = apiextensionsv1.JSONSchemaProps{Type: "string", Pattern: "^[A-Z]{2}-[0-9]{3}$"}
+	crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"] = spec
+
+	sample, err := GenerateSample(crd, "", false)
+	require.NoError(t, err)
+	assert.Contains(t, sample, "# Replace with a value matching ^[A-Z]{2}-[0-9]{3}$\n  code: \"\"\n")
+}
@@ -0,0 +1,125 @@
+package views
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SampleModel shows a generated sample manifest that can be copied or written to a file
+type SampleModel struct {
+	title     string
+	text      string
+	viewport  viewport.Model
+	fileInput textinput.Model
+	exporting bool
+	notice    string
+	err       error
+}
+
+// NewSampleModel creates a view for the sample text, filename is suggested when exporting
+func NewSampleModel(title, filename, text string, width, height int) *SampleModel {
+	vp := viewport.New(width, height-10)
+	vp.SetContent(text)
+
+	fi := textinput.New()
+	fi.Prompt = "Write to: "
+	fi.SetValue(filename)
+
+	return &SampleModel{
+		title:     title,
+		text:      text,
+		viewport:  vp,
+		fileInput: fi,
+	}
+}
+
+// Update handles key presses. It returns true when the view should be closed.
+func (m *SampleModel) Update(msg tea.KeyMsg) (exit bool, cmd tea.Cmd) {
+	if m.exporting {
+		switch msg.String() {
+		case "esc":
+			m.exporting = false
+			m.fileInput.Blur()
+			return false, nil
+		case "enter":
+			m.exporting = false
+			m.fileInput.Blur()
+			m.write(m.fileInput.Value())
+			return false, nil
+		}
+		m.fileInput, cmd = m.fileInput.Update(msg)
+		return false, cmd
+	}
+
+	switch msg.String() {
+	case "esc":
+		return true, nil
+	case "c":
+		m.notice, m.err = "", clipboard.WriteAll(m.text)
+		if m.err == nil {
+			m.notice = "Copied to clipboard"
+		}
+		return false, nil
+	case "w":
+		m.exporting = true
+		return false, m.fileInput.Focus()
+	}
+
+	m.viewport, cmd = m.viewport.Update(msg)
+	return false, cmd
+}
+
+// write saves the sample, existing files are not overwritten
+func (m *SampleModel) write(path string) {
+	m.notice = ""
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		m.err = err
+		return
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(m.text); err != nil {
+		m.err = err
+		return
+	}
+	m.err = nil
+	m.notice = fmt.Sprintf("Written to %s", path)
+}
+
+// IsPrompting returns true while the file name is being entered
+func (m *SampleModel) IsPrompting() bool {
+	return m.exporting
+}
+
+// View renders the sample and the result of the last action
+func (m *SampleModel) View() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 1).
+		Render("Sample: " + m.title + "  [c: Copy] [w: Write to File] [Esc: Back]")
+
+	var status string
+	switch {
+	case m.exporting:
+		status = m.fileInput.View()
+	case m.err != nil:
+		status = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("Error: " + m.err.Error())
+	case m.notice != "":
+		status = lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Render(m.notice)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"\n",
+		m.viewport.View(),
+		status,
+	)
+}
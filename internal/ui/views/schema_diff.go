@@ -0,0 +1,229 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// DiffKind classifies how a field differs between two schemas
+type DiffKind int
+
+const (
+	DiffUnchanged DiffKind = iota // Only descendants changed
+	DiffAdded
+	DiffRemoved
+	DiffChanged
+)
+
+func (k DiffKind) String() string {
+	switch k {
+	case DiffAdded:
+		return "added"
+	case DiffRemoved:
+		return "removed"
+	case DiffChanged:
+		return "changed"
+	default:
+		return ""
+	}
+}
+
+// SchemaDiff describes the difference of a single field and its children
+type SchemaDiff struct {
+	Name      string
+	FieldPath string
+	Kind      DiffKind
+	Changes   []string // Human readable changes, e.g. "type: string → integer"
+	Old       *SchemaField
+	New       *SchemaField
+	Children  []SchemaDiff
+}
+
+// DiffCRDVersions compares the schema of oldVersion in oldCRD with newVersion in newCRD
+func DiffCRDVersions(oldCRD *apiextensionsv1.CustomResourceDefinition, oldVersion string,
+	newCRD *apiextensionsv1.CustomResourceDefinition, newVersion string) []SchemaDiff {
+	return DiffSchemaFields(
+		ExtractCRDSchemaFieldsForVersion(oldCRD, oldVersion),
+		ExtractCRDSchemaFieldsForVersion(newCRD, newVersion),
+	)
+}
+
+// DiffSchemaFields compares two field trees by name. Only fields that changed,
+// or that have changed descendants, are returned.
+func DiffSchemaFields(oldFields, newFields []SchemaField) []SchemaDiff {
+	oldByName := make(map[string]*SchemaField, len(oldFields))
+	for i := range oldFields {
+		oldByName[oldFields[i].Name] = &oldFields[i]
+	}
+	newByName := make(map[string]*SchemaField, len(newFields))
+	for i := range newFields {
+		newByName[newFields[i].Name] = &newFields[i]
+	}
+
+	var diffs []SchemaDiff
+	for name, oldField := range oldByName {
+		newField, ok := newByName[name]
+		if !ok {
+			diffs = append(diffs, SchemaDiff{
+				Name:      name,
+				FieldPath: oldField.FieldPath,
+				Kind:      DiffRemoved,
+				Old:       oldField,
+				Children:  wholeTreeDiff(oldField.Children, DiffRemoved),
+			})
+			continue
+		}
+
+		d := SchemaDiff{
+			Name:      name,
+			FieldPath: newField.FieldPath,
+			Changes:   fieldChanges(oldField, newField),
+			Old:       oldField,
+			New:       newField,
+			Children:  DiffSchemaFields(oldField.Children, newField.Children),
+		}
+		if len(d.Changes) > 0 {
+			d.Kind = DiffChanged
+		}
+		if d.Kind != DiffUnchanged || len(d.Children) > 0 {
+			diffs = append(diffs, d)
+		}
+	}
+
+	for name, newField := range newByName {
+		if _, ok := oldByName[name]; !ok {
+			diffs = append(diffs, SchemaDiff{
+				Name:      name,
+				FieldPath: newField.FieldPath,
+				Kind:      DiffAdded,
+				New:       newField,
+				Children:  wholeTreeDiff(newField.Children, DiffAdded),
+			})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs
+}
+
+// CountSchemaDiffs returns the number of added, removed and changed fields in the tree
+func CountSchemaDiffs(diffs []SchemaDiff) (added, removed, changed int) {
+	for _, d := range diffs {
+		switch d.Kind {
+		case DiffAdded:
+			added++
+		case DiffRemoved:
+			removed++
+		case DiffChanged:
+			changed++
+		}
+		a, r, c := CountSchemaDiffs(d.Children)
+		added, removed, changed = added+a, removed+r, changed+c
+	}
+	return added, removed, changed
+}
+
+// wholeTreeDiff marks all fields of an added or removed subtree
+func wholeTreeDiff(fields []SchemaField, kind DiffKind) []SchemaDiff {
+	diffs := make([]SchemaDiff, 0, len(fields))
+	for i := range fields {
+		f := &fields[i]
+		d := SchemaDiff{Name: f.Name, FieldPath: f.FieldPath, Kind: kind, Children: wholeTreeDiff(f.Children, kind)}
+		if kind == DiffAdded {
+			d.New = f
+		} else {
+			d.Old = f
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs
+}
+
+// fieldChanges lists type, required and constraint changes between two versions of a field
+func fieldChanges(oldField, newField *SchemaField) []string {
+	var changes []string
+	change := func(name, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, fmt.Sprintf("%s: %s → %s", name, orNone(oldValue), orNone(newValue)))
+		}
+	}
+
+	change("type", oldField.Type, newField.Type)
+	change("required", yesNo(oldField.Required), yesNo(newField.Required))
+	change("nullable", yesNo(oldField.Nullable), yesNo(newField.Nullable))
+	change("format", oldField.Format, newField.Format)
+	change("pattern", oldField.Pattern, newField.Pattern)
+	change("default", oldField.Default, newField.Default)
+	change("enum", strings.Join(oldField.Enum, ", "), strings.Join(newField.Enum, ", "))
+
+	oldLimits, newLimits := limitsByName(oldField.Limits), limitsByName(newField.Limits)
+	for _, name := range unionKeys(oldLimits, newLimits) {
+		change(name, oldLimits[name], newLimits[name])
+	}
+
+	oldRules, newRules := rulesSet(oldField.Validations), rulesSet(newField.Validations)
+	for _, rule := range unionKeys(oldRules, newRules) {
+		switch {
+		case oldRules[rule] == "":
+			changes = append(changes, "rule added: "+rule)
+		case newRules[rule] == "":
+			changes = append(changes, "rule removed: "+rule)
+		}
+	}
+
+	return changes
+}
+
+// limitsByName splits formatted limits like "maxLength: 63" into name and value
+func limitsByName(limits []string) map[string]string {
+	m := make(map[string]string, len(limits))
+	for _, l := range limits {
+		name, value, found := strings.Cut(l, ": ")
+		if !found {
+			value = "yes" // Flags like uniqueItems
+		}
+		m[name] = value
+	}
+	return m
+}
+
+func rulesSet(rules []ValidationRule) map[string]string {
+	m := make(map[string]string, len(rules))
+	for _, r := range rules {
+		m[r.Rule] = r.Rule
+	}
+	return m
+}
+
+func unionKeys(a, b map[string]string) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
@@ -0,0 +1,141 @@
+package views
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/pteich/crdlens/internal/types"
+)
+
+func diffTestCRD() *apiextensionsv1.CustomResourceDefinition {
+	maxOld, maxNew := int64(63), int64(253)
+	return &apiextensionsv1.CustomResourceDefinition{
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name:   "v1beta1",
+					Served: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"spec": {
+									Type: "object",
+									Properties: map[string]apiextensionsv1.JSONSchemaProps{
+										"host":     {Type: "string", MaxLength: &maxOld},
+										"port":     {Type: "string"},
+										"legacy":   {Type: "boolean"},
+										"replicas": {Type: "integer"},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Name:    "v1",
+					Served:  true,
+					Storage: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"spec": {
+									Type:     "object",
+									Required: []string{"host"},
+									Properties: map[string]apiextensionsv1.JSONSchemaProps{
+										"host": {Type: "string", MaxLength: &maxNew},
+										"port": {Type: "integer"},
+										"tls": {
+											Type: "object",
+											Properties: map[string]apiextensionsv1.JSONSchemaProps{
+												"secretName": {Type: "string"},
+											},
+										},
+										"replicas": {Type: "integer"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDiffCRDVersions(t *testing.T) {
+	crd := diffTestCRD()
+	diffs := DiffCRDVersions(crd, "v1beta1", crd, "v1")
+
+	require.Len(t, diffs, 1)
+	spec := diffs[0]
+	assert.Equal(t, "spec", spec.Name)
+	assert.Equal(t, DiffUnchanged, spec.Kind, "only nested fields changed")
+
+	byName := make(map[string]SchemaDiff)
+	for _, d := range spec.Children {
+		byName[d.Name] = d
+	}
+	assert.NotContains(t, byName, "replicas", "unchanged fields are left out")
+
+	assert.Equal(t, DiffChanged, byName["host"].Kind)
+	assert.Equal(t, []string{"required: no → yes", "maxLength: 63 → 253"}, byName["host"].Changes)
+
+	assert.Equal(t, DiffChanged, byName["port"].Kind)
+	assert.Equal(t, []string{"type: string → integer"}, byName["port"].Changes)
+
+	assert.Equal(t, DiffRemoved, byName["legacy"].Kind)
+
+	tls := byName["tls"]
+	assert.Equal(t, DiffAdded, tls.Kind)
+	require.Len(t, tls.Children, 1)
+	assert.Equal(t, DiffAdded, tls.Children[0].Kind)
+
+	added, removed, changed := CountSchemaDiffs(diffs)
+	assert.Equal(t, 2, added)
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, 2, changed)
+
+	assert.Empty(t, DiffCRDVersions(crd, "v1", crd, "v1"))
+}
+
+func TestCRDSpecModel_DiffVersions(t *testing.T) {
+	crd := types.CRDInfo{Name: "widgets.example.com", Version: "v1beta1"}
+	m := NewCRDSpecModel(nil, crd, 160, 60)
+	m.Update(FetchedCRDSpecMsg{Spec: diffTestCRD()})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	require.True(t, m.HasActiveDialog())
+
+	// Options are "v1", "File...", "Cancel" with Cancel preselected
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, m.diffView)
+	assert.False(t, m.HasActiveDialog())
+	assert.True(t, m.HasNavigationHistory())
+	assert.Contains(t, m.View(), "2 added, 1 removed, 2 changed")
+
+	// Drill into spec and back out of the diff
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Contains(t, m.View(), "type: string → integer")
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, m.diffView)
+}
+
+func TestCRDSpecModel_DiffFileOtherCRD(t *testing.T) {
+	m := NewCRDSpecModel(nil, types.CRDInfo{Name: "widgets.example.com", Version: "v1beta1"}, 160, 60)
+	m.Update(FetchedCRDSpecMsg{Spec: diffTestCRD()})
+
+	other := diffTestCRD()
+	other.Name = "gadgets.example.com"
+	m.Update(CRDFileLoadedMsg{Path: "gadgets.yaml", CRDs: []*apiextensionsv1.CustomResourceDefinition{other}})
+
+	assert.Nil(t, m.diffView)
+	assert.EqualError(t, m.actionErr, "file does not contain widgets.example.com")
+}
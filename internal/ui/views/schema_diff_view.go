@@ -0,0 +1,201 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// diffNavState remembers a level of the diff tree while drilling down
+type diffNavState struct {
+	diffs  []SchemaDiff
+	cursor int
+	path   string
+}
+
+// SchemaDiffModel shows a schema diff as a navigable tree
+type SchemaDiffModel struct {
+	title    string
+	root     []SchemaDiff
+	current  []SchemaDiff
+	navStack []diffNavState
+	path     string
+	table    table.Model
+	width    int
+	height   int
+}
+
+// NewSchemaDiffModel creates a diff view for the given diff tree
+func NewSchemaDiffModel(title string, diffs []SchemaDiff, width, height int) *SchemaDiffModel {
+	columns := []table.Column{
+		{Title: "Field", Width: 40},
+		{Title: "Change", Width: 10},
+		{Title: "Details", Width: 60},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(height-16),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	t.SetStyles(s)
+
+	m := &SchemaDiffModel{
+		title:   title,
+		root:    diffs,
+		current: diffs,
+		table:   t,
+		width:   width,
+		height:  height,
+	}
+	m.updateTableRows()
+	return m
+}
+
+// Update handles key presses. It returns true when esc was pressed on the top level.
+func (m *SchemaDiffModel) Update(msg tea.KeyMsg) (exit bool, cmd tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		idx := m.table.Cursor()
+		if idx >= 0 && idx < len(m.current) && len(m.current[idx].Children) > 0 {
+			m.navStack = append(m.navStack, diffNavState{diffs: m.current, cursor: idx, path: m.path})
+			m.path = m.current[idx].FieldPath
+			m.current = m.current[idx].Children
+			m.updateTableRows()
+			m.table.SetCursor(0)
+		}
+		return false, nil
+
+	case "esc", "backspace":
+		if len(m.navStack) == 0 {
+			return true, nil
+		}
+		last := m.navStack[len(m.navStack)-1]
+		m.navStack = m.navStack[:len(m.navStack)-1]
+		m.current = last.diffs
+		m.path = last.path
+		m.updateTableRows()
+		m.table.SetCursor(last.cursor)
+		return false, nil
+	}
+
+	m.table, cmd = m.table.Update(msg)
+	return false, cmd
+}
+
+func (m *SchemaDiffModel) updateTableRows() {
+	rows := make([]table.Row, len(m.current))
+	for i, d := range m.current {
+		name := d.Name
+		if len(d.Children) > 0 {
+			name += " ▸"
+		}
+		rows[i] = table.Row{name, diffMarker(d.Kind), diffSummary(d)}
+	}
+	m.table.SetRows(rows)
+}
+
+// View renders the diff tree and the changes of the selected field
+func (m *SchemaDiffModel) View() string {
+	titleText := "Schema Diff: " + m.title
+	if m.path != "" {
+		titleText += "  " + m.path
+	}
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 1).
+		Render(titleText + "  [Enter: Drill Down] [Esc: Back]")
+
+	added, removed, changed := CountSchemaDiffs(m.root)
+	summary := lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Padding(0, 1).
+		Render(fmt.Sprintf("%d added, %d removed, %d changed", added, removed, changed))
+
+	if len(m.root) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, title, summary, "\n",
+			lipgloss.NewStyle().Padding(0, 1).Render("The schemas are identical."))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		summary,
+		"\n",
+		m.table.View(),
+		m.renderSelected(),
+	)
+}
+
+// renderSelected lists all changes of the selected field
+func (m *SchemaDiffModel) renderSelected() string {
+	idx := m.table.Cursor()
+	if idx < 0 || idx >= len(m.current) {
+		return ""
+	}
+	d := m.current[idx]
+
+	lines := []string{lipgloss.NewStyle().Bold(true).Render(d.FieldPath)}
+	switch d.Kind {
+	case DiffAdded:
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Render("+ added as "+d.New.Type))
+	case DiffRemoved:
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("- removed, was "+d.Old.Type))
+	default:
+		for _, c := range d.Changes {
+			lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Render("~ "+c))
+		}
+		if len(d.Changes) == 0 {
+			lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render("Only nested fields changed"))
+		}
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
+
+// CanGoBack returns true if the view can navigate up the tree
+func (m *SchemaDiffModel) CanGoBack() bool {
+	return len(m.navStack) > 0
+}
+
+func diffMarker(kind DiffKind) string {
+	switch kind {
+	case DiffAdded:
+		return "+ added"
+	case DiffRemoved:
+		return "- removed"
+	case DiffChanged:
+		return "~ changed"
+	default:
+		return ""
+	}
+}
+
+func diffSummary(d SchemaDiff) string {
+	switch d.Kind {
+	case DiffAdded:
+		return d.New.Type
+	case DiffRemoved:
+		return d.Old.Type
+	case DiffChanged:
+		return strings.Join(d.Changes, "; ")
+	default:
+		return "nested changes"
+	}
+}
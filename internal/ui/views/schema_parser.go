@@ -1,7 +1,10 @@
 package views
 
 import (
+	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 )
@@ -14,6 +17,39 @@ type SchemaField struct {
 	Description string
 	Required    bool
 	Children    []SchemaField
+
+	// Constraints a value has to satisfy
+	Enum        []string // Allowed values as JSON
+	Default     string   // Default value as JSON
+	Pattern     string
+	Format      string
+	Nullable    bool
+	Limits      []string // Formatted min/max constraints, e.g. "maxLength: 63"
+	Validations []ValidationRule
+
+	// Lower bounds, used to generate valid sample values
+	Minimum          *float64
+	ExclusiveMinimum bool
+	MinLength        *int64
+	MinItems         *int64
+
+	// Kubernetes schema extensions set on the field, e.g. "x-kubernetes-list-type: map"
+	Extensions []string
+}
+
+// ValidationRule is a CEL rule from x-kubernetes-validations
+type ValidationRule struct {
+	Rule              string
+	Message           string
+	MessageExpression string
+	FieldPath         string
+	Reason            string
+}
+
+// HasConstraints returns true if the field restricts its values beyond the type
+func (f SchemaField) HasConstraints() bool {
+	return len(f.Enum) > 0 || f.Default != "" || f.Pattern != "" || f.Format != "" ||
+		f.Nullable || len(f.Limits) > 0 || len(f.Validations) > 0
 }
 
 // ParseSchemaFields recursively extracts fields from a JSON Schema
@@ -50,48 +86,16 @@ func ParseSchemaFields(schema *apiextensionsv1.JSONSchemaProps, basePath string,
 			fieldPath = basePath + "." + propName
 		}
 
-		fieldType := "unknown"
-		if propSchema.Type != "" {
-			fieldType = string(propSchema.Type)
-		} else if len(propSchema.AnyOf) > 0 {
-			fieldType = "anyOf"
-		} else if len(propSchema.AllOf) > 0 {
-			fieldType = "allOf"
-		} else if len(propSchema.OneOf) > 0 {
-			fieldType = "oneOf"
-		}
-
-		required := requiredFields[propName]
-		description := propSchema.Description
-
 		field := SchemaField{
 			Name:        propName,
 			FieldPath:   fieldPath,
-			Type:        fieldType,
-			Description: description,
-			Required:    required,
-		}
-
-		// Check for nested children
-		if fieldType == "object" && len(propSchema.Properties) > 0 {
-			nestedRequired := make(map[string]bool)
-			for _, req := range propSchema.Required {
-				nestedRequired[req] = true
-			}
-			field.Children = ParseSchemaFields(&propSchema, fieldPath, nestedRequired)
-		} else if fieldType == "array" && propSchema.Items != nil && propSchema.Items.Schema != nil {
-			if len(propSchema.Items.Schema.Properties) > 0 {
-				field.Type = "array of object"
-				nestedRequired := make(map[string]bool)
-				for _, req := range propSchema.Items.Schema.Required {
-					nestedRequired[req] = true
-				}
-				field.Children = ParseSchemaFields(propSchema.Items.Schema, fieldPath+"[]", nestedRequired)
-			} else if propSchema.Items.Schema.Type != "" {
-				itemType := string(propSchema.Items.Schema.Type)
-				field.Type = "array of " + itemType
-			}
+			Type:        schemaType(&propSchema),
+			Description: propSchema.Description,
+			Required:    requiredFields[propName],
+			Extensions:  schemaExtensions(&propSchema),
 		}
+		applyConstraints(&field, &propSchema)
+		field.Children = schemaChildren(&propSchema, fieldPath)
 
 		fields = append(fields, field)
 	}
@@ -104,6 +108,234 @@ func ParseSchemaFields(schema *apiextensionsv1.JSONSchemaProps, basePath string,
 	return fields
 }
 
+// schemaType returns a readable type name that includes the Kubernetes schema extensions
+func schemaType(schema *apiextensionsv1.JSONSchemaProps) string {
+	switch {
+	case schema.XIntOrString:
+		return "int-or-string"
+	case schema.XEmbeddedResource:
+		return "embedded resource"
+	}
+
+	preserveUnknown := schema.XPreserveUnknownFields != nil && *schema.XPreserveUnknownFields
+
+	switch schema.Type {
+	case "array":
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return "array"
+		}
+		itemType := schemaType(schema.Items.Schema)
+		if schema.XListType != nil {
+			switch *schema.XListType {
+			case "map":
+				if len(schema.XListMapKeys) > 0 {
+					return "list-map keyed by " + strings.Join(schema.XListMapKeys, ", ")
+				}
+				return "list-map of " + itemType
+			case "set":
+				return "set of " + itemType
+			}
+		}
+		return "array of " + itemType
+
+	case "object":
+		if len(schema.Properties) == 0 && schema.AdditionalProperties != nil {
+			if schema.AdditionalProperties.Schema != nil {
+				return "map[string]" + schemaType(schema.AdditionalProperties.Schema)
+			}
+			if schema.AdditionalProperties.Allows {
+				return "map[string]any"
+			}
+		}
+		if preserveUnknown {
+			if len(schema.Properties) == 0 {
+				return "object (any fields)"
+			}
+			return "object (+unknown fields)"
+		}
+		return "object"
+
+	case "":
+		if kind, alternatives := schemaAlternatives(schema); len(alternatives) > 0 {
+			types := make([]string, len(alternatives))
+			for i := range alternatives {
+				types[i] = schemaType(&alternatives[i])
+			}
+			return kind + ": " + strings.Join(types, " | ")
+		}
+		if len(schema.Properties) > 0 {
+			return "object"
+		}
+		if preserveUnknown {
+			return "any"
+		}
+		return "unknown"
+	}
+
+	return string(schema.Type)
+}
+
+// schemaChildren returns the fields to drill into: properties, array items, map values and alternatives
+func schemaChildren(schema *apiextensionsv1.JSONSchemaProps, fieldPath string) []SchemaField {
+	if schema.XIntOrString {
+		// The anyOf of int-or-string fields is fully described by the type
+		return nil
+	}
+
+	var children []SchemaField
+	switch {
+	case len(schema.Properties) > 0:
+		children = ParseSchemaFields(schema, fieldPath, requiredSet(schema))
+	case schema.Type == "array" && schema.Items != nil && schema.Items.Schema != nil:
+		children = schemaChildren(schema.Items.Schema, fieldPath+"[]")
+	case schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil:
+		children = schemaChildren(schema.AdditionalProperties.Schema, fieldPath+".*")
+	}
+
+	kind, alternatives := schemaAlternatives(schema)
+	for i := range alternatives {
+		alt := &alternatives[i]
+		name := fmt.Sprintf("%s[%d]", kind, i)
+		field := SchemaField{
+			Name:        name,
+			FieldPath:   fmt.Sprintf("%s (%s)", fieldPath, name),
+			Type:        schemaType(alt),
+			Description: alt.Description,
+			Extensions:  schemaExtensions(alt),
+		}
+		applyConstraints(&field, alt)
+		field.Children = schemaChildren(alt, fieldPath)
+		children = append(children, field)
+	}
+
+	return children
+}
+
+// schemaAlternatives returns the anyOf, oneOf or allOf schemas that describe a value.
+// Alternatives that only add constraints like required fields are skipped.
+func schemaAlternatives(schema *apiextensionsv1.JSONSchemaProps) (string, []apiextensionsv1.JSONSchemaProps) {
+	for _, group := range []struct {
+		kind    string
+		schemas []apiextensionsv1.JSONSchemaProps
+	}{
+		{"anyOf", schema.AnyOf},
+		{"oneOf", schema.OneOf},
+		{"allOf", schema.AllOf},
+	} {
+		var alternatives []apiextensionsv1.JSONSchemaProps
+		for _, alt := range group.schemas {
+			if alt.Type != "" || len(alt.Properties) > 0 || alt.Items != nil || alt.AdditionalProperties != nil ||
+				len(alt.AnyOf) > 0 || len(alt.OneOf) > 0 || len(alt.AllOf) > 0 {
+				alternatives = append(alternatives, alt)
+			}
+		}
+		if len(alternatives) > 0 {
+			return group.kind, alternatives
+		}
+	}
+	return "", nil
+}
+
+// schemaExtensions lists the x-kubernetes-* extensions set on the schema
+func schemaExtensions(schema *apiextensionsv1.JSONSchemaProps) []string {
+	var ext []string
+	if schema.XIntOrString {
+		ext = append(ext, "x-kubernetes-int-or-string")
+	}
+	if schema.XPreserveUnknownFields != nil && *schema.XPreserveUnknownFields {
+		ext = append(ext, "x-kubernetes-preserve-unknown-fields")
+	}
+	if schema.XEmbeddedResource {
+		ext = append(ext, "x-kubernetes-embedded-resource")
+	}
+	if schema.XListType != nil {
+		ext = append(ext, "x-kubernetes-list-type: "+*schema.XListType)
+	}
+	if len(schema.XListMapKeys) > 0 {
+		ext = append(ext, "x-kubernetes-list-map-keys: "+strings.Join(schema.XListMapKeys, ", "))
+	}
+	if schema.XMapType != nil {
+		ext = append(ext, "x-kubernetes-map-type: "+*schema.XMapType)
+	}
+	return ext
+}
+
+// requiredSet returns the required properties of an object schema
+func requiredSet(schema *apiextensionsv1.JSONSchemaProps) map[string]bool {
+	required := make(map[string]bool, len(schema.Required))
+	for _, req := range schema.Required {
+		required[req] = true
+	}
+	return required
+}
+
+// applyConstraints copies value constraints and CEL rules from the schema onto the field
+func applyConstraints(field *SchemaField, schema *apiextensionsv1.JSONSchemaProps) {
+	for _, e := range schema.Enum {
+		field.Enum = append(field.Enum, string(e.Raw))
+	}
+	if schema.Default != nil {
+		field.Default = string(schema.Default.Raw)
+	}
+	field.Pattern = schema.Pattern
+	field.Format = schema.Format
+	field.Nullable = schema.Nullable
+	field.Minimum = schema.Minimum
+	field.ExclusiveMinimum = schema.ExclusiveMinimum
+	field.MinLength = schema.MinLength
+	field.MinItems = schema.MinItems
+
+	field.Limits = appendNumberLimit(field.Limits, "minimum", schema.Minimum, schema.ExclusiveMinimum)
+	field.Limits = appendNumberLimit(field.Limits, "maximum", schema.Maximum, schema.ExclusiveMaximum)
+	if schema.MultipleOf != nil {
+		field.Limits = append(field.Limits, "multipleOf: "+formatNumber(*schema.MultipleOf))
+	}
+	field.Limits = appendCountLimit(field.Limits, "minLength", schema.MinLength)
+	field.Limits = appendCountLimit(field.Limits, "maxLength", schema.MaxLength)
+	field.Limits = appendCountLimit(field.Limits, "minItems", schema.MinItems)
+	field.Limits = appendCountLimit(field.Limits, "maxItems", schema.MaxItems)
+	if schema.UniqueItems {
+		field.Limits = append(field.Limits, "uniqueItems")
+	}
+	field.Limits = appendCountLimit(field.Limits, "minProperties", schema.MinProperties)
+	field.Limits = appendCountLimit(field.Limits, "maxProperties", schema.MaxProperties)
+
+	for _, v := range schema.XValidations {
+		rule := ValidationRule{
+			Rule:              v.Rule,
+			Message:           v.Message,
+			MessageExpression: v.MessageExpression,
+			FieldPath:         v.FieldPath,
+		}
+		if v.Reason != nil {
+			rule.Reason = string(*v.Reason)
+		}
+		field.Validations = append(field.Validations, rule)
+	}
+}
+
+func appendNumberLimit(limits []string, name string, value *float64, exclusive bool) []string {
+	if value == nil {
+		return limits
+	}
+	limit := fmt.Sprintf("%s: %s", name, formatNumber(*value))
+	if exclusive {
+		limit += " (exclusive)"
+	}
+	return append(limits, limit)
+}
+
+func appendCountLimit(limits []string, name string, value *int64) []string {
+	if value == nil {
+		return limits
+	}
+	return append(limits, fmt.Sprintf("%s: %d", name, *value))
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
 // FlattenSchemaFields converts a hierarchical list of fields into a flat list
 func FlattenSchemaFields(fields []SchemaField) []SchemaField {
 	var flat []SchemaField
@@ -120,26 +352,32 @@ func FlattenSchemaFields(fields []SchemaField) []SchemaField {
 	return flat
 }
 
-// ExtractCRDSchemaFields extracts all fields from a CRD's schema
+// ExtractCRDSchemaFields extracts all fields from the first served version of a CRD's schema
 func ExtractCRDSchemaFields(crd *apiextensionsv1.CustomResourceDefinition) []SchemaField {
+	return ExtractCRDSchemaFieldsForVersion(crd, "")
+}
+
+// ExtractCRDSchemaFieldsForVersion extracts all fields from the schema of the given served version.
+// An empty version selects the first served version with a schema.
+func ExtractCRDSchemaFieldsForVersion(crd *apiextensionsv1.CustomResourceDefinition, version string) []SchemaField {
 	if crd == nil || len(crd.Spec.Versions) == 0 {
 		return nil
 	}
 
-	for _, version := range crd.Spec.Versions {
-		if !version.Served {
+	for _, v := range crd.Spec.Versions {
+		if !v.Served || (version != "" && v.Name != version) {
 			continue
 		}
 
-		if version.Schema != nil && version.Schema.OpenAPIV3Schema != nil {
+		if v.Schema != nil && v.Schema.OpenAPIV3Schema != nil {
 			requiredFields := make(map[string]bool)
-			for _, req := range version.Schema.OpenAPIV3Schema.Required {
+			for _, req := range v.Schema.OpenAPIV3Schema.Required {
 				requiredFields[req] = true
 			}
 
 			// We treat the top level schema as the source of fields.
 			// The top level is usually an object.
-			return ParseSchemaFields(version.Schema.OpenAPIV3Schema, "", requiredFields)
+			return ParseSchemaFields(v.Schema.OpenAPIV3Schema, "", requiredFields)
 		}
 	}
 
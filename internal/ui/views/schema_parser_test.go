@@ -155,6 +155,242 @@ func TestExtractCRDSchemaFields_WithVersions(t *testing.T) {
 	assert.Equal(t, "Specification", fields[0].Description)
 }
 
+func TestExtractCRDSchemaFieldsForVersion(t *testing.T) {
+	versionSchema := func(field string) *apiextensionsv1.CustomResourceValidation {
+		return &apiextensionsv1.CustomResourceValidation{
+			OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					field: {Type: "string"},
+				},
+			},
+		}
+	}
+
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: false, Schema: versionSchema("old")},
+				{Name: "v1beta1", Served: true, Schema: versionSchema("beta")},
+				{Name: "v1", Served: true, Storage: true, Schema: versionSchema("stable")},
+			},
+		},
+	}
+
+	fields := ExtractCRDSchemaFieldsForVersion(crd, "v1")
+	require.Len(t, fields, 1)
+	assert.Equal(t, "stable", fields[0].Name)
+
+	// Empty version falls back to the first served version
+	fields = ExtractCRDSchemaFieldsForVersion(crd, "")
+	require.Len(t, fields, 1)
+	assert.Equal(t, "beta", fields[0].Name)
+
+	// Versions that are not served are never used
+	assert.Empty(t, ExtractCRDSchemaFieldsForVersion(crd, "v1alpha1"))
+}
+
+func TestParseSchemaFields_Constraints(t *testing.T) {
+	minLen, maxLen := int64(1), int64(63)
+	maxReplicas := float64(10)
+	minReplicas := float64(0)
+	reason := apiextensionsv1.FieldValueForbidden
+
+	schema := &apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"name": {
+				Type:      "string",
+				Pattern:   "^[a-z0-9-]+$",
+				MinLength: &minLen,
+				MaxLength: &maxLen,
+			},
+			"mode": {
+				Type:    "string",
+				Enum:    []apiextensionsv1.JSON{{Raw: []byte(`"Auto"`)}, {Raw: []byte(`"Manual"`)}},
+				Default: &apiextensionsv1.JSON{Raw: []byte(`"Auto"`)},
+			},
+			"replicas": {
+				Type:             "integer",
+				Format:           "int32",
+				Minimum:          &minReplicas,
+				Maximum:          &maxReplicas,
+				ExclusiveMaximum: true,
+				Nullable:         true,
+				XValidations: apiextensionsv1.ValidationRules{
+					{Rule: "self >= oldSelf", Message: "replicas cannot be reduced", Reason: &reason},
+				},
+			},
+			"plain": {Type: "string"},
+		},
+	}
+
+	fields := ParseSchemaFields(schema, "", nil)
+
+	name := findField(t, fields, "name")
+	assert.Equal(t, "^[a-z0-9-]+$", name.Pattern)
+	assert.Equal(t, []string{"minLength: 1", "maxLength: 63"}, name.Limits)
+
+	mode := findField(t, fields, "mode")
+	assert.Equal(t, []string{`"Auto"`, `"Manual"`}, mode.Enum)
+	assert.Equal(t, `"Auto"`, mode.Default)
+
+	replicas := findField(t, fields, "replicas")
+	assert.Equal(t, "int32", replicas.Format)
+	assert.True(t, replicas.Nullable)
+	assert.Equal(t, []string{"minimum: 0", "maximum: 10 (exclusive)"}, replicas.Limits)
+	require.Len(t, replicas.Validations, 1)
+	assert.Equal(t, "self >= oldSelf", replicas.Validations[0].Rule)
+	assert.Equal(t, "replicas cannot be reduced", replicas.Validations[0].Message)
+	assert.Equal(t, "FieldValueForbidden", replicas.Validations[0].Reason)
+	assert.True(t, replicas.HasConstraints())
+
+	assert.False(t, findField(t, fields, "plain").HasConstraints())
+}
+
+func TestParseSchemaFields_KubernetesExtensions(t *testing.T) {
+	preserve := true
+	listMap := "map"
+	set := "set"
+
+	schema := &apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"port": {
+				XIntOrString: true,
+				AnyOf: []apiextensionsv1.JSONSchemaProps{
+					{Type: "integer"},
+					{Type: "string"},
+				},
+			},
+			"values": {
+				Type:                   "object",
+				XPreserveUnknownFields: &preserve,
+			},
+			"raw": {
+				XPreserveUnknownFields: &preserve,
+			},
+			"template": {
+				Type:              "object",
+				XEmbeddedResource: true,
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"kind": {Type: "string"},
+				},
+			},
+			"labels": {
+				Type: "object",
+				AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{
+					Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"},
+				},
+			},
+			"resources": {
+				Type: "object",
+				AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{
+					Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"limit": {Type: "string"},
+						},
+					},
+				},
+			},
+			"ports": {
+				Type:         "array",
+				XListType:    &listMap,
+				XListMapKeys: []string{"name", "protocol"},
+				Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+					Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"name": {Type: "string"},
+						},
+					},
+				},
+			},
+			"finalizers": {
+				Type:      "array",
+				XListType: &set,
+				Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+					Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"},
+				},
+			},
+		},
+	}
+
+	fields := ParseSchemaFields(schema, "", nil)
+
+	port := findField(t, fields, "port")
+	assert.Equal(t, "int-or-string", port.Type)
+	assert.Empty(t, port.Children, "int-or-string alternatives are covered by the type")
+	assert.Equal(t, []string{"x-kubernetes-int-or-string"}, port.Extensions)
+
+	assert.Equal(t, "object (any fields)", findField(t, fields, "values").Type)
+	assert.Equal(t, "any", findField(t, fields, "raw").Type)
+
+	template := findField(t, fields, "template")
+	assert.Equal(t, "embedded resource", template.Type)
+	assert.Len(t, template.Children, 1)
+
+	assert.Equal(t, "map[string]string", findField(t, fields, "labels").Type)
+
+	resources := findField(t, fields, "resources")
+	assert.Equal(t, "map[string]object", resources.Type)
+	require.Len(t, resources.Children, 1)
+	assert.Equal(t, "resources.*.limit", resources.Children[0].FieldPath)
+
+	ports := findField(t, fields, "ports")
+	assert.Equal(t, "list-map keyed by name, protocol", ports.Type)
+	require.Len(t, ports.Children, 1)
+	assert.Equal(t, "ports[].name", ports.Children[0].FieldPath)
+
+	assert.Equal(t, "set of string", findField(t, fields, "finalizers").Type)
+}
+
+func TestParseSchemaFields_Alternatives(t *testing.T) {
+	schema := &apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"source": {
+				OneOf: []apiextensionsv1.JSONSchemaProps{
+					{Type: "string", Description: "URL of the source"},
+					{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"git": {Type: "string"},
+						},
+					},
+				},
+			},
+			"exclusive": {
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"a": {Type: "string"},
+					"b": {Type: "string"},
+				},
+				// Only constraints, nothing to drill into
+				OneOf: []apiextensionsv1.JSONSchemaProps{
+					{Required: []string{"a"}},
+					{Required: []string{"b"}},
+				},
+			},
+		},
+	}
+
+	fields := ParseSchemaFields(schema, "", nil)
+
+	source := findField(t, fields, "source")
+	assert.Equal(t, "oneOf: string | object", source.Type)
+	require.Len(t, source.Children, 2)
+	assert.Equal(t, "oneOf[0]", source.Children[0].Name)
+	assert.Equal(t, "URL of the source", source.Children[0].Description)
+	require.Len(t, source.Children[1].Children, 1)
+	assert.Equal(t, "source.git", source.Children[1].Children[0].FieldPath)
+
+	exclusive := findField(t, fields, "exclusive")
+	assert.Equal(t, "object", exclusive.Type)
+	assert.Len(t, exclusive.Children, 2)
+}
+
 func findField(t *testing.T, fields []SchemaField, name string) SchemaField {
 	t.Helper()
 	for _, field := range fields {
@@ -0,0 +1,145 @@
+package views
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// SelectorKind distinguishes label from field selectors
+type SelectorKind int
+
+const (
+	LabelSelector SelectorKind = iota
+	FieldSelector
+)
+
+// maxSelectorHistory limits how many selectors are remembered per kind
+const maxSelectorHistory = 20
+
+// selectorHistory remembers applied selectors across CR lists, newest first
+var selectorHistory = map[SelectorKind][]string{}
+
+// SelectorPrompt asks for a label or field selector and validates it before it is applied
+type SelectorPrompt struct {
+	kind       SelectorKind
+	input      textinput.Model
+	err        error
+	historyPos int // -1 while editing, otherwise the shown history entry
+}
+
+// NewSelectorPrompt creates a prompt prefilled with the current selector
+func NewSelectorPrompt(kind SelectorKind, current string) *SelectorPrompt {
+	ti := textinput.New()
+	ti.Prompt = "Label selector: "
+	ti.Placeholder = "app=web,tier in (frontend,backend),!canary"
+	if kind == FieldSelector {
+		ti.Prompt = "Field selector: "
+		ti.Placeholder = "metadata.name=example,metadata.namespace!=kube-system"
+	}
+	ti.SetValue(current)
+	ti.Focus()
+
+	return &SelectorPrompt{kind: kind, input: ti, historyPos: -1}
+}
+
+// Update handles a key press. It returns done=true once the prompt was closed,
+// apply is true if the selector in Value() is valid and should be used.
+// An empty selector is valid and removes the filter.
+func (p *SelectorPrompt) Update(msg tea.KeyMsg) (done, apply bool) {
+	history := selectorHistory[p.kind]
+
+	switch msg.String() {
+	case "esc":
+		return true, false
+	case "enter":
+		if p.err = validateSelector(p.kind, p.input.Value()); p.err != nil {
+			return false, false
+		}
+		rememberSelector(p.kind, p.Value())
+		return true, true
+	case "up":
+		if p.historyPos < len(history)-1 {
+			p.historyPos++
+			p.input.SetValue(history[p.historyPos])
+			p.input.CursorEnd()
+		}
+		return false, false
+	case "down":
+		if p.historyPos > 0 {
+			p.historyPos--
+			p.input.SetValue(history[p.historyPos])
+			p.input.CursorEnd()
+		} else if p.historyPos == 0 {
+			p.historyPos = -1
+			p.input.SetValue("")
+		}
+		return false, false
+	}
+
+	p.input, _ = p.input.Update(msg)
+	p.err = nil
+	return false, false
+}
+
+// Value returns the entered selector in its normalized form
+func (p *SelectorPrompt) Value() string {
+	value := p.input.Value()
+	switch p.kind {
+	case LabelSelector:
+		if sel, err := labels.Parse(value); err == nil {
+			return sel.String()
+		}
+	case FieldSelector:
+		if sel, err := fields.ParseSelector(value); err == nil {
+			return sel.String()
+		}
+	}
+	return value
+}
+
+// Kind returns whether the prompt asks for a label or a field selector
+func (p *SelectorPrompt) Kind() SelectorKind {
+	return p.kind
+}
+
+// View renders the input, validation errors and a hint for the history
+func (p *SelectorPrompt) View() string {
+	view := p.input.View()
+	if p.err != nil {
+		view = lipgloss.JoinVertical(lipgloss.Left, view,
+			lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("Invalid selector: "+p.err.Error()))
+	}
+	hint := "[Enter] Apply  [Esc] Cancel"
+	if len(selectorHistory[p.kind]) > 0 {
+		hint += "  [↑/↓] History"
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, view,
+		lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(hint))
+}
+
+// validateSelector parses a selector with the same rules as the API server
+func validateSelector(kind SelectorKind, value string) error {
+	if kind == FieldSelector {
+		_, err := fields.ParseSelector(value)
+		return err
+	}
+	_, err := labels.Parse(value)
+	return err
+}
+
+// rememberSelector adds a selector to the front of the history, duplicates are moved
+func rememberSelector(kind SelectorKind, value string) {
+	if value == "" {
+		return
+	}
+	history := []string{value}
+	for _, h := range selectorHistory[kind] {
+		if h != value && len(history) < maxSelectorHistory {
+			history = append(history, h)
+		}
+	}
+	selectorHistory[kind] = history
+}
@@ -0,0 +1,51 @@
+package views
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func typeText(p *SelectorPrompt, text string) {
+	for _, r := range text {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestSelectorPrompt(t *testing.T) {
+	selectorHistory = map[SelectorKind][]string{}
+
+	p := NewSelectorPrompt(LabelSelector, "")
+	typeText(p, "app in (web")
+	done, apply := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, done, "invalid selectors keep the prompt open")
+	assert.False(t, apply)
+	assert.Contains(t, p.View(), "Invalid selector")
+
+	typeText(p, ")")
+	done, apply = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, done)
+	assert.True(t, apply)
+	assert.Equal(t, "app in (web)", p.Value())
+
+	p = NewSelectorPrompt(LabelSelector, "")
+	typeText(p, "tier=db")
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// History is newest first and separate per kind
+	p = NewSelectorPrompt(LabelSelector, "")
+	p.Update(tea.KeyMsg{Type: tea.KeyUp})
+	assert.Equal(t, "tier=db", p.Value())
+	p.Update(tea.KeyMsg{Type: tea.KeyUp})
+	assert.Equal(t, "app in (web)", p.Value())
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, "tier=db", p.Value())
+
+	p = NewSelectorPrompt(FieldSelector, "metadata.name=foo")
+	p.Update(tea.KeyMsg{Type: tea.KeyUp})
+	assert.Equal(t, "metadata.name=foo", p.Value())
+	done, apply = p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, done)
+	assert.False(t, apply)
+}
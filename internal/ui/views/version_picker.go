@@ -0,0 +1,134 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/pteich/crdlens/internal/types"
+)
+
+// VersionPicker is a modal list of the served versions of a CRD
+type VersionPicker struct {
+	crd      types.CRDInfo
+	versions []types.CRDVersion
+	cursor   int
+}
+
+// NewVersionPicker creates a picker with the currently selected version preselected
+func NewVersionPicker(crd types.CRDInfo) *VersionPicker {
+	p := &VersionPicker{
+		crd:      crd,
+		versions: crd.ServedVersions(),
+	}
+	for i, v := range p.versions {
+		if v.Name == crd.Version {
+			p.cursor = i
+		}
+	}
+	return p
+}
+
+// Update handles a key press. It returns done=true once a version was chosen
+// (version is its name) or the picker was dismissed (version is empty).
+func (p *VersionPicker) Update(msg tea.KeyMsg) (done bool, version string) {
+	switch msg.String() {
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(p.versions)-1 {
+			p.cursor++
+		}
+	case "enter":
+		if len(p.versions) == 0 {
+			return true, ""
+		}
+		return true, p.versions[p.cursor].Name
+	case "esc":
+		return true, ""
+	}
+	return false, ""
+}
+
+// View renders the picker centered in the given area
+func (p *VersionPicker) View(width, height int) string {
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+
+	lines := []string{fmt.Sprintf("Versions of %s", p.crd.Name), ""}
+	for i, v := range p.versions {
+		label := "  " + v.Name + versionTags(v)
+		if i == p.cursor {
+			label = selectedStyle.Render(label)
+		}
+		lines = append(lines, label)
+		if v.Deprecated && v.DeprecationWarning != "" {
+			lines = append(lines, warnStyle.Render("    "+v.DeprecationWarning))
+		}
+	}
+
+	lines = append(lines, "", dimStyle.Render("Conversion: "+p.crd.Conversion))
+	for _, v := range p.crd.Versions {
+		if v.Storage && !v.Served {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf("Storage version %s is not served", v.Name)))
+		}
+	}
+	lines = append(lines, "", "[↑/↓] Select  [Enter] Use version  [Esc] Cancel")
+
+	picker := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7D56F4")).
+		Padding(1, 2).
+		Width(60).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(
+		width,
+		height,
+		lipgloss.Center,
+		lipgloss.Center,
+		picker,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color("#1a1a1a")),
+	)
+}
+
+// versionTags returns the storage and deprecation markers of a version
+func versionTags(v types.CRDVersion) string {
+	var tags []string
+	if v.Storage {
+		tags = append(tags, "storage")
+	}
+	if v.Deprecated {
+		tags = append(tags, "deprecated")
+	}
+	if len(tags) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(tags, ", ") + ")"
+}
+
+// versionLabel summarizes the selected version for the CRD list
+func versionLabel(crd types.CRDInfo) string {
+	label := crd.Version
+	if crd.CurrentVersion().Deprecated {
+		label += " ⚠"
+	}
+	if n := len(crd.ServedVersions()); n > 1 {
+		label += fmt.Sprintf(" +%d", n-1)
+	}
+	return label
+}
+
+// CRDVersionSelectedMsg is sent when a different version of a CRD was picked
+type CRDVersionSelectedMsg struct {
+	CRDName string
+	Version string
+}
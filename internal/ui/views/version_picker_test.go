@@ -0,0 +1,74 @@
+package views
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/pteich/crdlens/internal/types"
+)
+
+func testMultiVersionCRD() types.CRDInfo {
+	return types.CRDInfo{
+		Name:       "widgets.example.com",
+		Kind:       "Widget",
+		Version:    "v1",
+		GVR:        schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"},
+		Conversion: "Webhook",
+		Versions: []types.CRDVersion{
+			{Name: "v1beta1", Served: true, Deprecated: true, DeprecationWarning: "use v1"},
+			{Name: "v1", Served: true, Storage: true},
+			{Name: "v1alpha1"},
+		},
+	}
+}
+
+func TestVersionPicker(t *testing.T) {
+	p := NewVersionPicker(testMultiVersionCRD())
+
+	view := p.View(100, 40)
+	assert.Contains(t, view, "v1 (storage)")
+	assert.Contains(t, view, "v1beta1 (deprecated)")
+	assert.Contains(t, view, "use v1")
+	assert.Contains(t, view, "Conversion: Webhook")
+	assert.NotContains(t, view, "v1alpha1", "versions that are not served can't be picked")
+
+	// The current version is preselected
+	done, version := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, done)
+	assert.Equal(t, "v1", version)
+
+	p.Update(tea.KeyMsg{Type: tea.KeyUp})
+	done, version = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, done)
+	assert.Equal(t, "v1beta1", version)
+
+	done, version = p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, done)
+	assert.Empty(t, version)
+}
+
+func TestCRDListModel_SelectVersion(t *testing.T) {
+	m := NewCRDListModel(nil, "", 120, 40, true)
+	m.Update(FetchedCRDsMsg{CRDs: []types.CRDInfo{testMultiVersionCRD()}})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	require.True(t, m.HasActiveDialog())
+
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.False(t, m.HasActiveDialog())
+
+	m.Update(cmd())
+	selected := m.SelectedCRD()
+	assert.Equal(t, "v1beta1", selected.Version)
+	assert.Equal(t, "v1beta1", selected.GVR.Version)
+
+	// The choice survives a refresh
+	m.Update(FetchedCRDsMsg{CRDs: []types.CRDInfo{testMultiVersionCRD()}})
+	assert.Equal(t, "v1beta1", m.SelectedCRD().GVR.Version)
+}